@@ -15,71 +15,77 @@ func main() {
 	basebot.Start("\x00Golor Bot", models.Training, desiredGameSettings, calculateMove)
 }
 
-const MaxInt = int(^uint(0) >> 1)
+// how far to look for a better place to explode a carried power-up
+const explosionPlanMoves = 3
 
 var (
 	moves   = []models.Action{models.Right, models.Down, models.Left, models.Up} // models.Explode, models.Stay}
 	lastDir = 0
-	graph   maputility.Graph = nil
+	// distances only depend on the obstacles, keep them between ticks and games
+	distances = maputility.NewDistanceCache(maputility.DefaultDistanceCacheBytes)
+	tracker   = maputility.NewGameTracker(maputility.DefaultTrackerWindow)
+	opponents = maputility.NewOpponentModel()
 )
 
 // Implement your paintbot here
 func calculateMove(settings models.GameSettings, updateEvent models.MapUpdateEvent) models.Action {
 	utility := maputility.New(updateEvent.Map, nil, *updateEvent.ReceivingPlayerID)
-	me := utility.GetMe()
-	if graph == nil {
-		fmt.Println("making map")
-		graph = maputility.GraphOfMap(*utility)
+	utility.SetGraph(distances.Graph(utility))
+	utility.SetGameSettings(settings)
+	delta := tracker.Update(updateEvent, settings)
+	utility.SetTracker(tracker)
+	if previous, ok := tracker.Map(1); ok {
+		opponents.Observe(previous, delta.Actions)
+	}
+	me, ok := utility.GetPlayer(*updateEvent.ReceivingPlayerID)
+	if !ok {
+		return models.Stay
 	}
-
-	utility.SetGraph(graph)
-
 	if me.StunnedForTicks() > 0 {
 		return models.Stay
 	}
 
-	powerupCoordinates := utility.ListCoordinatesContainingPowerUps()
-
-	if len(powerupCoordinates) == 0 {
-		return models.Up
+	// don't be the bot who runs into another bot the whole game
+	collisions := maputility.NewCollisionPredictor(utility, me.GetID())
+	collisions.Model = opponents.Predict
+
+	if me.HasPowerUp() {
+		if plan, ok := utility.BestExplosionPlan(me.GetID(), explosionPlanMoves); ok && plan.Points > 0 {
+			if move, ok := plan.Path.NextAction(); ok {
+				return safeOrStay(collisions, move)
+			}
+			fmt.Println("get them!")
+			return models.Explode
+		}
 	}
 
-	distanceToClosestPowerUpCoord := MaxInt
-	var closestPowerUpCoord models.Coordinates
-
-	for _, coord := range powerupCoordinates {
-		dist, err := utility.DistanceTo(coord)
-		if err != nil {
-			fmt.Println(err)
-			continue
-		}
-		if dist <= distanceToClosestPowerUpCoord {
-			//fmt.Println(dist)
-			distanceToClosestPowerUpCoord = dist
-			closestPowerUpCoord = coord
-		}
+	closestPowerUp, ok := utility.NearestWhere(me.GetPos(), utility.IsPowerUp)
+	if !ok {
+		return models.Up
 	}
+	closestPowerUpCoord := closestPowerUp.Coordinates
 
-	path, err := utility.ShortestPathTo(closestPowerUpCoord)
+	// other players are in the way, wait for them to move
+	path, err := utility.ShortestPathAvoiding(closestPowerUpCoord, utility.OpponentCoordinates())
 	if err != nil {
-		panic("ojoj")
+		fmt.Println(err)
+		return models.Stay
 	}
-	//myPos := utility.ConvertCoordinatesToPosition(utility.GetMyCoordinates())
-	//closestPos := utility.ConvertCoordinatesToPosition(closestPowerUpCoord)
-	//bestPath := pathMatrix[myPos][closestPos]
-	//path := bestPath.Path
-	//fmt.Println(path)
-	if distanceToClosestPowerUpCoord == 1 && me.HasPowerUp() {
-		fmt.Println("don't waste")
-		return models.Explode
-		//fmt.Println(distanceToClosestPowerUpCoord)
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debug("\n" + utility.RenderWith(maputility.RenderOptions{Colour: true, Overlay: path.Coordinates()}))
 	}
-	if utility.IsAnyPlayerWithinExplosionRange() && me.HasPowerUp() {
-		fmt.Println("get them!")
-		return models.Explode
+	move, ok := path.NextAction()
+	if !ok {
+		return models.Stay
+	}
+	return safeOrStay(collisions, move)
+}
+
+// safeOrStay returns the move, or Stay if it risks colliding with another player
+func safeOrStay(collisions *maputility.CollisionPredictor, move models.Action) models.Action {
+	if len(collisions.SafeActions([]models.Action{move})) == 0 {
+		return models.Stay
 	}
-	nextPos := path[1] // [0] is the current position
-	move := utility.DirectionToPoint(nextPos)
 	return move
 }
 
@@ -100,21 +106,6 @@ func init() {
 }
 
 // desired game settings can be changed to nil to get default settings
-var desiredGameSettings = &models.GameSettings{
-	MaxNOOFPlayers:                 5,
-	TimeInMSPerTick:                250,
-	ObstaclesEnabled:               true,
-	PowerUpsEnabled:                true,
-	AddPowerUpLikelihood:           38,
-	RemovePowerUpLikelihood:        5,
-	TrainingGame:                   true,
-	PointsPerTileOwned:             1,
-	PointsPerCausedStun:            5,
-	NOOFTicksInvulnerableAfterStun: 3,
-	NOOFTicksStunned:               10,
-	StartObstacles:                 40,
-	StartPowerUps:                  41,
-	GameDurationInSeconds:          15,
-	ExplosionRange:                 4,
-	PointsPerTick:                  false,
-}
+var desiredGameSettings = models.DefaultGameSettingsWith(models.GameSettingsOverrides{
+	TrainingGame: models.Bool(true),
+})
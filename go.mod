@@ -3,9 +3,8 @@ module paintbot-client
 go 1.16
 
 require (
-	github.com/RyanCarrier/dijkstra v1.0.0 // indirect
 	github.com/gorilla/websocket v1.4.2
 	github.com/sirupsen/logrus v1.8.1
-	github.com/stretchr/testify v1.7.0 // indirect
+	github.com/stretchr/testify v1.7.0
 	golang.org/x/sys v0.0.0-20210403161142-5e06dd20ab57 // indirect
 )
@@ -0,0 +1,116 @@
+package models
+
+// Add returns the component wise sum of c and o
+func (c Coordinates) Add(o Coordinates) Coordinates {
+	return Coordinates{X: c.X + o.X, Y: c.Y + o.Y}
+}
+
+// Sub returns the component wise difference c - o
+func (c Coordinates) Sub(o Coordinates) Coordinates {
+	return Coordinates{X: c.X - o.X, Y: c.Y - o.Y}
+}
+
+// ManhattanDistance returns the number of moves between c and o on an empty map
+func (c Coordinates) ManhattanDistance(o Coordinates) int {
+	d := c.Sub(o)
+	return abs(d.X) + abs(d.Y)
+}
+
+// ChebyshevDistance returns the largest of the distances along the two axes
+func (c Coordinates) ChebyshevDistance(o Coordinates) int {
+	d := c.Sub(o)
+	return max(abs(d.X), abs(d.Y))
+}
+
+// IsAdjacent returns true if o can be reached from c with a single movement
+func (c Coordinates) IsAdjacent(o Coordinates) bool {
+	return c.ManhattanDistance(o) == 1
+}
+
+// Translate returns the coordinates after the given action has been performed successfully.
+// The second return value is false for unknown actions.
+func (c Coordinates) Translate(a Action) (Coordinates, bool) {
+	d, ok := a.Delta()
+	if !ok {
+		return c, false
+	}
+	return c.Add(d), true
+}
+
+// ActionTo returns the action that moves c to o.
+// Stay is returned when c equals o and false when o is not adjacent to c.
+func (c Coordinates) ActionTo(o Coordinates) (Action, bool) {
+	return ActionFromDelta(o.Sub(c))
+}
+
+// Neighbours returns the four coordinates reachable with a single movement,
+// in the order of Movements. Bounds and obstacles are not considered.
+func (c Coordinates) Neighbours() []Coordinates {
+	ns := make([]Coordinates, len(Movements))
+	for i, a := range Movements {
+		ns[i], _ = c.Translate(a)
+	}
+	return ns
+}
+
+// Delta returns the change in coordinates caused by performing the action.
+// Stay and Explode do not move the player. The second return value is false for unknown actions.
+func (a Action) Delta() (Coordinates, bool) {
+	switch a {
+	case Left:
+		return Coordinates{X: -1}, true
+	case Right:
+		return Coordinates{X: 1}, true
+	case Up:
+		return Coordinates{Y: -1}, true
+	case Down:
+		return Coordinates{Y: 1}, true
+	case Stay, Explode:
+		return Coordinates{}, true
+	default:
+		return Coordinates{}, false
+	}
+}
+
+// IsMovement returns true for the actions that change the position of the player
+func (a Action) IsMovement() bool {
+	switch a {
+	case Left, Right, Up, Down:
+		return true
+	default:
+		return false
+	}
+}
+
+// ActionFromDelta returns the action causing the given change in coordinates.
+// A zero delta gives Stay, deltas not reachable with a single action give false.
+func ActionFromDelta(d Coordinates) (Action, bool) {
+	switch d {
+	case Coordinates{}:
+		return Stay, true
+	case Coordinates{X: -1}:
+		return Left, true
+	case Coordinates{X: 1}:
+		return Right, true
+	case Coordinates{Y: -1}:
+		return Up, true
+	case Coordinates{Y: 1}:
+		return Down, true
+	default:
+		return Stay, false
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
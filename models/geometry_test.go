@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoordinates_Distances(t *testing.T) {
+	a := Coordinates{X: 1, Y: 2}
+	b := Coordinates{X: 4, Y: 0}
+
+	assert.Equal(t, 5, a.ManhattanDistance(b))
+	assert.Equal(t, 3, a.ChebyshevDistance(b))
+	assert.Equal(t, Coordinates{X: 5, Y: 2}, a.Add(b))
+	assert.Equal(t, Coordinates{X: -3, Y: 2}, a.Sub(b))
+}
+
+func TestCoordinates_ActionRoundTrip(t *testing.T) {
+	c := Coordinates{X: 3, Y: 3}
+	for _, a := range append(Movements, Stay) {
+		n, ok := c.Translate(a)
+		assert.True(t, ok)
+		back, ok := c.ActionTo(n)
+		assert.True(t, ok)
+		assert.Equal(t, a, back)
+	}
+
+	_, ok := c.ActionTo(Coordinates{X: 4, Y: 4})
+	assert.False(t, ok)
+
+	_, ok = c.Translate("JUMP")
+	assert.False(t, ok)
+}
+
+func TestRegions(t *testing.T) {
+	d := Diamond{Center: Coordinates{X: 0, Y: 0}, Radius: 1}
+	assert.Len(t, d.Coordinates(), 5)
+	assert.True(t, d.Contains(Coordinates{X: 0, Y: -1}))
+	assert.False(t, d.Contains(Coordinates{X: 1, Y: 1}))
+
+	r := Ring{Center: Coordinates{X: 2, Y: 2}, Radius: 2}
+	assert.Len(t, r.Coordinates(), 8)
+
+	clipped := Clip(d, MapBounds(3, 3))
+	assert.ElementsMatch(t, []Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}, clipped)
+
+	rect := Rect(1, 1, 3, 4)
+	assert.Len(t, rect.Coordinates(), 6)
+	assert.False(t, rect.Contains(Coordinates{X: 3, Y: 1}))
+}
@@ -0,0 +1,142 @@
+package models
+
+// Region is a set of coordinates on the map
+type Region interface {
+	// Contains returns true if the coordinate is part of the region
+	Contains(c Coordinates) bool
+	// Bounds returns the smallest rectangle containing the whole region
+	Bounds() Rectangle
+	// Coordinates returns all the coordinates of the region in row major order
+	Coordinates() []Coordinates
+}
+
+// Rectangle is the region Min.X <= X < Max.X, Min.Y <= Y < Max.Y
+type Rectangle struct {
+	Min Coordinates
+	Max Coordinates
+}
+
+// Rect returns the rectangle with the corners {x0, y0} and {x1, y1}, the max corner excluded
+func Rect(x0, y0, x1, y1 int) Rectangle {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	return Rectangle{Min: Coordinates{X: x0, Y: y0}, Max: Coordinates{X: x1, Y: y1}}
+}
+
+// MapBounds returns the rectangle covering a map of the given size
+func MapBounds(width, height int) Rectangle {
+	return Rect(0, 0, width, height)
+}
+
+func (r Rectangle) Width() int {
+	return r.Max.X - r.Min.X
+}
+
+func (r Rectangle) Height() int {
+	return r.Max.Y - r.Min.Y
+}
+
+// Empty returns true if the rectangle contains no coordinates
+func (r Rectangle) Empty() bool {
+	return r.Min.X >= r.Max.X || r.Min.Y >= r.Max.Y
+}
+
+func (r Rectangle) Contains(c Coordinates) bool {
+	return r.Min.X <= c.X && c.X < r.Max.X && r.Min.Y <= c.Y && c.Y < r.Max.Y
+}
+
+func (r Rectangle) Bounds() Rectangle {
+	return r
+}
+
+// Intersect returns the largest rectangle contained in both r and o
+func (r Rectangle) Intersect(o Rectangle) Rectangle {
+	if r.Min.X < o.Min.X {
+		r.Min.X = o.Min.X
+	}
+	if r.Min.Y < o.Min.Y {
+		r.Min.Y = o.Min.Y
+	}
+	if r.Max.X > o.Max.X {
+		r.Max.X = o.Max.X
+	}
+	if r.Max.Y > o.Max.Y {
+		r.Max.Y = o.Max.Y
+	}
+	if r.Empty() {
+		return Rectangle{}
+	}
+	return r
+}
+
+func (r Rectangle) Coordinates() []Coordinates {
+	return coordinatesIn(r, r)
+}
+
+// Diamond is the region of all coordinates within Radius moves of Center
+type Diamond struct {
+	Center Coordinates
+	Radius int
+}
+
+func (d Diamond) Contains(c Coordinates) bool {
+	return d.Radius >= 0 && d.Center.ManhattanDistance(c) <= d.Radius
+}
+
+func (d Diamond) Bounds() Rectangle {
+	if d.Radius < 0 {
+		return Rectangle{}
+	}
+	return squareAround(d.Center, d.Radius)
+}
+
+func (d Diamond) Coordinates() []Coordinates {
+	return coordinatesIn(d, d.Bounds())
+}
+
+// Ring is the region of all coordinates exactly Radius moves from Center
+type Ring struct {
+	Center Coordinates
+	Radius int
+}
+
+func (r Ring) Contains(c Coordinates) bool {
+	return r.Center.ManhattanDistance(c) == r.Radius
+}
+
+func (r Ring) Bounds() Rectangle {
+	if r.Radius < 0 {
+		return Rectangle{}
+	}
+	return squareAround(r.Center, r.Radius)
+}
+
+func (r Ring) Coordinates() []Coordinates {
+	return coordinatesIn(r, r.Bounds())
+}
+
+// Clip returns the coordinates of the region that lie within the bounds
+func Clip(r Region, bounds Rectangle) []Coordinates {
+	return coordinatesIn(r, r.Bounds().Intersect(bounds))
+}
+
+func squareAround(c Coordinates, radius int) Rectangle {
+	return Rect(c.X-radius, c.Y-radius, c.X+radius+1, c.Y+radius+1)
+}
+
+func coordinatesIn(r Region, bounds Rectangle) []Coordinates {
+	var cs []Coordinates
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := Coordinates{X: x, Y: y}
+			if r.Contains(c) {
+				cs = append(cs, c)
+			}
+		}
+	}
+	return cs
+}
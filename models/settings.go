@@ -0,0 +1,95 @@
+package models
+
+// DefaultGameSettings returns the settings the server uses when a player
+// registers without any desired game settings.
+func DefaultGameSettings() GameSettings {
+	return GameSettings{
+		MaxNOOFPlayers:                 5,
+		TimeInMSPerTick:                250,
+		ObstaclesEnabled:               true,
+		PowerUpsEnabled:                true,
+		AddPowerUpLikelihood:           38,
+		RemovePowerUpLikelihood:        5,
+		TrainingGame:                   false,
+		PointsPerTileOwned:             1,
+		PointsPerCausedStun:            5,
+		NOOFTicksInvulnerableAfterStun: 3,
+		NOOFTicksStunned:               10,
+		StartObstacles:                 40,
+		StartPowerUps:                  41,
+		GameDurationInSeconds:          15,
+		ExplosionRange:                 4,
+		PointsPerTick:                  false,
+	}
+}
+
+// GameSettingsOverrides is a partial GameSettings. Only the fields that are
+// set (non nil) replace the corresponding field when merged.
+type GameSettingsOverrides struct {
+	MaxNOOFPlayers                 *int
+	TimeInMSPerTick                *int
+	ObstaclesEnabled               *bool
+	PowerUpsEnabled                *bool
+	AddPowerUpLikelihood           *int
+	RemovePowerUpLikelihood        *int
+	TrainingGame                   *bool
+	PointsPerTileOwned             *int
+	PointsPerCausedStun            *int
+	NOOFTicksInvulnerableAfterStun *int
+	NOOFTicksStunned               *int
+	StartObstacles                 *int
+	StartPowerUps                  *int
+	GameDurationInSeconds          *int
+	ExplosionRange                 *int
+	PointsPerTick                  *bool
+}
+
+// Int returns a pointer to the given value, for use in GameSettingsOverrides
+func Int(v int) *int {
+	return &v
+}
+
+// Bool returns a pointer to the given value, for use in GameSettingsOverrides
+func Bool(v bool) *bool {
+	return &v
+}
+
+// MergeGameSettings returns a copy of base with all the set fields of
+// overrides applied
+func MergeGameSettings(base GameSettings, overrides GameSettingsOverrides) GameSettings {
+	mergeInt(&base.MaxNOOFPlayers, overrides.MaxNOOFPlayers)
+	mergeInt(&base.TimeInMSPerTick, overrides.TimeInMSPerTick)
+	mergeBool(&base.ObstaclesEnabled, overrides.ObstaclesEnabled)
+	mergeBool(&base.PowerUpsEnabled, overrides.PowerUpsEnabled)
+	mergeInt(&base.AddPowerUpLikelihood, overrides.AddPowerUpLikelihood)
+	mergeInt(&base.RemovePowerUpLikelihood, overrides.RemovePowerUpLikelihood)
+	mergeBool(&base.TrainingGame, overrides.TrainingGame)
+	mergeInt(&base.PointsPerTileOwned, overrides.PointsPerTileOwned)
+	mergeInt(&base.PointsPerCausedStun, overrides.PointsPerCausedStun)
+	mergeInt(&base.NOOFTicksInvulnerableAfterStun, overrides.NOOFTicksInvulnerableAfterStun)
+	mergeInt(&base.NOOFTicksStunned, overrides.NOOFTicksStunned)
+	mergeInt(&base.StartObstacles, overrides.StartObstacles)
+	mergeInt(&base.StartPowerUps, overrides.StartPowerUps)
+	mergeInt(&base.GameDurationInSeconds, overrides.GameDurationInSeconds)
+	mergeInt(&base.ExplosionRange, overrides.ExplosionRange)
+	mergeBool(&base.PointsPerTick, overrides.PointsPerTick)
+	return base
+}
+
+// DefaultGameSettingsWith returns the server defaults with the given overrides applied
+func DefaultGameSettingsWith(overrides GameSettingsOverrides) *GameSettings {
+	s := MergeGameSettings(DefaultGameSettings(), overrides)
+	return &s
+}
+
+func mergeInt(dst *int, v *int) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func mergeBool(dst *bool, v *bool) {
+	if v != nil {
+		*dst = *v
+	}
+}
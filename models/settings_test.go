@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeGameSettings_emptyOverridesKeepDefaults(t *testing.T) {
+	assert.Equal(t, DefaultGameSettings(), MergeGameSettings(DefaultGameSettings(), GameSettingsOverrides{}))
+	assert.Equal(t, DefaultGameSettings(), *DefaultGameSettingsWith(GameSettingsOverrides{}))
+}
+
+func TestMergeGameSettings_partialOverride(t *testing.T) {
+	merged := MergeGameSettings(DefaultGameSettings(), GameSettingsOverrides{
+		ExplosionRange: Int(6),
+		TrainingGame:   Bool(true),
+	})
+
+	expected := DefaultGameSettings()
+	expected.ExplosionRange = 6
+	expected.TrainingGame = true
+	assert.Equal(t, expected, merged)
+}
+
+func TestMergeGameSettings_falseOverridesTrue(t *testing.T) {
+	assert.True(t, DefaultGameSettings().ObstaclesEnabled)
+
+	merged := DefaultGameSettingsWith(GameSettingsOverrides{ObstaclesEnabled: Bool(false)})
+	assert.False(t, merged.ObstaclesEnabled)
+	assert.True(t, merged.PowerUpsEnabled)
+}
@@ -0,0 +1,152 @@
+package maputility
+
+import (
+	"container/heap"
+	"fmt"
+
+	"paintbot-client/models"
+)
+
+// CostFunc returns the cost of moving from a tile onto the neighbouring tile to.
+// A negative cost makes the move impossible.
+type CostFunc func(from, to models.Coordinates) int
+
+// Heuristic estimates the remaining cost from a tile to the destination.
+// The path found is only guaranteed to be the cheapest if the estimate never
+// exceeds the real cost.
+type Heuristic func(from, to models.Coordinates) int
+
+// UnitCost makes every move cost 1, giving the path with the fewest steps
+func UnitCost(from, to models.Coordinates) int {
+	return 1
+}
+
+// ManhattanHeuristic is admissible as long as no move costs less than 1
+func ManhattanHeuristic(from, to models.Coordinates) int {
+	return from.ManhattanDistance(to)
+}
+
+// PathOptions configure FindPath
+type PathOptions struct {
+	// Cost of every move, UnitCost if nil
+	Cost CostFunc
+	// Heuristic guiding the search, a plain Dijkstra search is done if nil
+	Heuristic Heuristic
+	// Blockers are treated as obstacles, the start is never blocked
+	Blockers []models.Coordinates
+}
+
+// FindPath returns the cheapest path from one tile to another together with its total cost.
+// Players are not considered blocking unless given as Blockers.
+// If the destination is unreachable an error is returned.
+func (u *MapUtility) FindPath(from, to models.Coordinates, opts PathOptions) (Path, int, error) {
+	if u.IsCoordinatesOutOfBounds(from) {
+		return Path{}, 0, fmt.Errorf("coordinates are out of bounds: %v", from)
+	}
+	if !u.IsTileAvailableForMovementTo(to) || u.IsCoordinatesOutOfBounds(to) {
+		return Path{}, 0, fmt.Errorf("coordinates are unreachable: %v", to)
+	}
+
+	cost := opts.Cost
+	if cost == nil {
+		cost = UnitCost
+	}
+	estimate := func(models.Coordinates) int { return 0 }
+	if opts.Heuristic != nil {
+		estimate = func(c models.Coordinates) int { return opts.Heuristic(c, to) }
+	}
+
+	g := u.tiles()
+	blocked := u.blockedBy(opts.Blockers)
+	start := u.ConvertCoordinatesToPosition(from)
+	goal := u.ConvertCoordinatesToPosition(to)
+
+	best := make([]int, len(g.tiles))
+	parent := make([]int, len(g.tiles))
+	for i := range best {
+		best[i] = Unreachable
+		parent[i] = Unreachable
+	}
+	best[start] = 0
+
+	open := &searchQueue{{pos: start, priority: estimate(from)}}
+	var neighbours [4]int
+	for open.Len() > 0 {
+		current := heap.Pop(open).(searchNode)
+		if current.cost > best[current.pos] {
+			// a cheaper way to this position was found after it was queued
+			continue
+		}
+		if current.pos == goal {
+			return u.ConvertPositionsToPath(reconstructPath(parent, goal)), current.cost, nil
+		}
+
+		currentCoord := u.ConvertPositionToCoordinates(current.pos)
+		for _, n := range g.neighbours(current.pos, neighbours[:0]) {
+			if !g.walkable(n) || (blocked != nil && blocked(n)) {
+				continue
+			}
+			nCoord := u.ConvertPositionToCoordinates(n)
+			step := cost(currentCoord, nCoord)
+			if step < 0 {
+				continue
+			}
+			c := current.cost + step
+			if best[n] != Unreachable && best[n] <= c {
+				continue
+			}
+			best[n] = c
+			parent[n] = current.pos
+			heap.Push(open, searchNode{pos: n, cost: c, priority: c + estimate(nCoord)})
+		}
+	}
+	return Path{}, 0, fmt.Errorf("coordinates are unreachable: %v", to)
+}
+
+// PaintingCost makes moves onto tiles the player has already coloured cost ownTileCost
+// and all other moves cost 1, so the cheapest path colours as many new tiles as possible
+func (u *MapUtility) PaintingCost(playerID string, ownTileCost int) CostFunc {
+	owner, ok := u.playerIndex()[playerID]
+	if !ok {
+		return UnitCost
+	}
+	g := u.tiles()
+	return func(from, to models.Coordinates) int {
+		if g.owner(u.ConvertCoordinatesToPosition(to)) == owner {
+			return ownTileCost
+		}
+		return 1
+	}
+}
+
+func reconstructPath(parent []int, goal int) []int {
+	var reversed []int
+	for pos := goal; pos != Unreachable; pos = parent[pos] {
+		reversed = append(reversed, pos)
+	}
+	path := make([]int, len(reversed))
+	for i := range reversed {
+		path[i] = reversed[len(reversed)-1-i]
+	}
+	return path
+}
+
+type searchNode struct {
+	pos      int
+	cost     int
+	priority int
+}
+
+// searchQueue is a min heap of search nodes ordered by priority
+type searchQueue []searchNode
+
+func (q searchQueue) Len() int            { return len(q) }
+func (q searchQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q searchQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *searchQueue) Push(x interface{}) { *q = append(*q, x.(searchNode)) }
+func (q *searchQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
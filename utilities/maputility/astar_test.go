@@ -0,0 +1,55 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_FindPath(t *testing.T) {
+	// 0 1 2
+	// 3 # 5
+	// 6 7 8
+	mu := New(models.Map{
+		Width:               3,
+		Height:              3,
+		ObstacleUpPositions: []int{4},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0, ColouredPosition: []int{0, 1, 2}},
+		},
+	}, nil, "myId")
+
+	from := models.Coordinates{X: 0, Y: 0}
+	to := models.Coordinates{X: 2, Y: 2}
+
+	path, cost, err := mu.FindPath(from, to, PathOptions{Heuristic: ManhattanHeuristic})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, cost)
+	assert.Equal(t, 4, path.Len())
+
+	// going right first would only cross tiles we already own
+	path, cost, err = mu.FindPath(from, to, PathOptions{
+		Cost:      mu.PaintingCost("myId", 3),
+		Heuristic: ManhattanHeuristic,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, cost)
+	assert.Equal(t, []int{0, 3, 6, 7, 8}, path.Positions())
+
+	_, _, err = mu.FindPath(from, to, PathOptions{
+		Blockers: []models.Coordinates{{X: 1, Y: 0}, {X: 0, Y: 1}},
+	})
+	assert.Error(t, err)
+
+	impassable := func(from, to models.Coordinates) int {
+		if to.X == 0 && to.Y == 1 {
+			return -1
+		}
+		return 1
+	}
+	path, _, err = mu.FindPath(from, to, PathOptions{Cost: impassable})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 5, 8}, path.Positions())
+}
@@ -0,0 +1,189 @@
+package maputility
+
+import (
+	"errors"
+
+	"paintbot-client/models"
+)
+
+// Unreachable is the distance reported for tiles that cannot be reached from the source
+const Unreachable = -1
+
+var ErrNoPath = errors.New("no path exists between the positions")
+
+// DistanceField holds the distance from a source position to every position
+// on the map together with the previous position on a shortest path.
+// It is computed with a single breadth first search over the map.
+type DistanceField struct {
+	source int
+	width  int
+	dist   []int
+	parent []int
+}
+
+// bfs computes the distance field from source over all walkable positions
+// of the grid. Positions for which blocked returns true are never entered,
+// blocked may be nil.
+func bfs(g *tileGrid, source int, blocked func(pos int) bool) *DistanceField {
+	return bfsUntil(g, source, blocked, nil)
+}
+
+// bfsUntil is bfs stopping as soon as done returns true for a position taken off the queue,
+// in which case only the positions searched so far have their distances set. done may be nil.
+func bfsUntil(g *tileGrid, source int, blocked func(pos int) bool, done func(pos, dist int) bool) *DistanceField {
+	size := len(g.tiles)
+	f := &DistanceField{
+		source: source,
+		width:  g.width,
+		dist:   make([]int, size),
+		parent: make([]int, size),
+	}
+	for i := range f.dist {
+		f.dist[i] = Unreachable
+		f.parent[i] = Unreachable
+	}
+	if !g.inRange(source) {
+		return f
+	}
+
+	queue := make([]int, 0, size)
+	queue = append(queue, source)
+	f.dist[source] = 0
+
+	var neighbours [4]int
+	for head := 0; head < len(queue); head++ {
+		pos := queue[head]
+		if done != nil && done(pos, f.dist[pos]) {
+			break
+		}
+		for _, n := range g.neighbours(pos, neighbours[:0]) {
+			if f.dist[n] != Unreachable || !g.walkable(n) || (blocked != nil && blocked(n)) {
+				continue
+			}
+			f.dist[n] = f.dist[pos] + 1
+			f.parent[n] = pos
+			queue = append(queue, n)
+		}
+	}
+	return f
+}
+
+// neighbours appends the in bounds positions next to pos to buf, in the order of models.Movements
+func (g *tileGrid) neighbours(pos int, buf []int) []int {
+	x := pos % g.width
+	if x > 0 {
+		buf = append(buf, pos-1)
+	}
+	if x < g.width-1 {
+		buf = append(buf, pos+1)
+	}
+	if pos >= g.width {
+		buf = append(buf, pos-g.width)
+	}
+	if pos+g.width < len(g.tiles) {
+		buf = append(buf, pos+g.width)
+	}
+	return buf
+}
+
+// Source returns the position the distances are measured from
+func (f *DistanceField) Source() int {
+	return f.source
+}
+
+// Distance returns the number of moves needed to reach the position, or Unreachable
+func (f *DistanceField) Distance(pos int) int {
+	if pos < 0 || pos >= len(f.dist) {
+		return Unreachable
+	}
+	return f.dist[pos]
+}
+
+// DistanceTo returns the number of moves needed to reach the coordinates
+// and false if they cannot be reached
+func (f *DistanceField) DistanceTo(c models.Coordinates) (int, bool) {
+	pos, ok := f.position(c)
+	if !ok {
+		return Unreachable, false
+	}
+	d := f.dist[pos]
+	return d, d != Unreachable
+}
+
+// Path returns the positions of a shortest path from the source to pos,
+// starting with the source. Returns nil if pos cannot be reached.
+func (f *DistanceField) Path(pos int) []int {
+	d := f.Distance(pos)
+	if d == Unreachable {
+		return nil
+	}
+	path := make([]int, d+1)
+	for i := d; i >= 0; i-- {
+		path[i] = pos
+		pos = f.parent[pos]
+	}
+	return path
+}
+
+// PathTo returns the positions of a shortest path from the source to the coordinates
+// and false if they cannot be reached
+func (f *DistanceField) PathTo(c models.Coordinates) ([]int, bool) {
+	pos, ok := f.position(c)
+	if !ok {
+		return nil, false
+	}
+	path := f.Path(pos)
+	return path, path != nil
+}
+
+// Reachable returns all positions that can be reached from the source, the source included
+func (f *DistanceField) Reachable() []int {
+	var positions []int
+	for pos, d := range f.dist {
+		if d != Unreachable {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+func (f *DistanceField) position(c models.Coordinates) (int, bool) {
+	if f.width <= 0 || c.X < 0 || c.Y < 0 || c.X >= f.width {
+		return 0, false
+	}
+	pos := c.Y*f.width + c.X
+	return pos, pos < len(f.dist)
+}
+
+// DistanceFieldFrom returns the distances from the given coordinates to every
+// tile on the map. Players are not considered blocking, see DistanceFieldAvoiding.
+func (u *MapUtility) DistanceFieldFrom(source models.Coordinates) *DistanceField {
+	return u.DistanceFieldAvoiding(source, nil)
+}
+
+// sourcePosition returns the position of the coordinates or Unreachable if they are out of bounds
+func (u *MapUtility) sourcePosition(c models.Coordinates) int {
+	if u.IsCoordinatesOutOfBounds(c) {
+		return Unreachable
+	}
+	return u.ConvertCoordinatesToPosition(c)
+}
+
+// gridGraph is the Graph of a map, searched with breadth first search.
+// The distance field of the most recent source is kept, so repeated
+// queries from the same position only search the map once.
+type gridGraph struct {
+	grid *tileGrid
+	last *DistanceField
+}
+
+func (g *gridGraph) Shortest(from int, to int) (BestPath, error) {
+	if g.last == nil || g.last.source != from {
+		g.last = bfs(g.grid, from, nil)
+	}
+	path := g.last.Path(to)
+	if path == nil {
+		return BestPath{}, ErrNoPath
+	}
+	return BestPath{Distance: int64(len(path) - 1), Path: path}, nil
+}
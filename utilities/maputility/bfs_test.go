@@ -0,0 +1,81 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_DistanceFieldFrom(t *testing.T) {
+	// 0 1 2
+	// # # 5
+	// 6 7 8
+	mu := New(models.Map{Width: 3, Height: 3, ObstacleUpPositions: []int{3, 4}}, nil, "")
+	f := mu.DistanceFieldFrom(models.Coordinates{X: 0, Y: 0})
+
+	d, ok := f.DistanceTo(models.Coordinates{X: 0, Y: 2})
+	assert.True(t, ok)
+	assert.Equal(t, 6, d)
+
+	path, ok := f.PathTo(models.Coordinates{X: 0, Y: 2})
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1, 2, 5, 8, 7, 6}, path)
+
+	_, ok = f.DistanceTo(models.Coordinates{X: 0, Y: 1})
+	assert.False(t, ok)
+	assert.Equal(t, Unreachable, f.Distance(4))
+	assert.Len(t, f.Reachable(), 7)
+}
+
+func TestMapUtility_DistanceToWithoutGraph(t *testing.T) {
+	mu := New(models.Map{
+		Width:          5,
+		Height:         5,
+		CharacterInfos: []models.CharacterInfo{{ID: "myId", Position: 0}},
+	}, nil, "myId")
+
+	d, err := mu.DistanceTo(models.Coordinates{X: 4, Y: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 8, d)
+
+	path, err := mu.ShortestPathTo(models.Coordinates{X: 1, Y: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, path.Positions())
+}
+
+func BenchmarkMapUtility_DistanceFieldFrom(b *testing.B) {
+	mu := New(fullSizeMap(), nil, "a")
+	me := mu.GetMe().GetPos()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mu.DistanceFieldFrom(me)
+	}
+}
+
+func TestMapUtility_ShortestPathAvoiding(t *testing.T) {
+	// 0 1 2
+	// 3 4 5
+	// 6 7 8
+	mu := New(models.Map{
+		Width:  3,
+		Height: 3,
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0},
+			{ID: "other", Position: 1},
+		},
+	}, nil, "myId")
+
+	path, err := mu.ShortestPathAvoiding(models.Coordinates{X: 2, Y: 0}, mu.OpponentCoordinates())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 3, 4, 5, 2}, path.Positions())
+
+	_, err = mu.DistanceToAvoiding(models.Coordinates{X: 2, Y: 0}, mu.OpponentNextCoordinates())
+	assert.Error(t, err)
+
+	d, err := mu.DistanceTo(models.Coordinates{X: 2, Y: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, d)
+}
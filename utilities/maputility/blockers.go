@@ -0,0 +1,64 @@
+package maputility
+
+import "paintbot-client/models"
+
+// DistanceFieldAvoiding returns the distances from the source to every tile on the map
+// when the given coordinates are treated as obstacles, on top of the obstacles of the map.
+// The source itself is never blocked.
+func (u *MapUtility) DistanceFieldAvoiding(source models.Coordinates, blockers []models.Coordinates) *DistanceField {
+	return bfs(u.tiles(), u.sourcePosition(source), u.blockedBy(blockers))
+}
+
+// DistanceToAvoiding returns the distance from the current player to the destination
+// when the given coordinates are treated as obstacles
+func (u *MapUtility) DistanceToAvoiding(destination models.Coordinates, blockers []models.Coordinates) (int, error) {
+	return u.DistanceToAvoidingFrom(u.currentPlayerID, destination, blockers)
+}
+
+// ShortestPathAvoiding returns the shortest path from the current player to the destination
+// when the given coordinates are treated as obstacles.
+// If the destination is unreachable an error is returned.
+func (u *MapUtility) ShortestPathAvoiding(destination models.Coordinates, blockers []models.Coordinates) (Path, error) {
+	return u.ShortestPathAvoidingFrom(u.currentPlayerID, destination, blockers)
+}
+
+// OpponentCoordinates returns the current coordinates of all opponents,
+// for use as blockers
+func (u *MapUtility) OpponentCoordinates() []models.Coordinates {
+	return u.OpponentCoordinatesOf(u.currentPlayerID)
+}
+
+// OpponentNextCoordinates returns every coordinate an opponent may occupy after the next tick:
+// their current coordinates and, unless they are stunned, the walkable tiles next to them
+func (u *MapUtility) OpponentNextCoordinates() []models.Coordinates {
+	var coords []models.Coordinates
+	for _, o := range u.Opponents() {
+		pos := o.GetPos()
+		coords = append(coords, pos)
+		if o.StunnedForTicks() > 0 {
+			continue
+		}
+		for _, n := range pos.Neighbours() {
+			if u.IsTileAvailableForMovementTo(n) {
+				coords = append(coords, n)
+			}
+		}
+	}
+	return coords
+}
+
+// blockedBy returns a lookup of the in bounds positions of the coordinates
+func (u *MapUtility) blockedBy(blockers []models.Coordinates) func(pos int) bool {
+	if len(blockers) == 0 {
+		return nil
+	}
+	mask := make([]bool, len(u.tiles().tiles))
+	for _, c := range blockers {
+		if !u.IsCoordinatesOutOfBounds(c) {
+			mask[u.ConvertCoordinatesToPosition(c)] = true
+		}
+	}
+	return func(pos int) bool {
+		return mask[pos]
+	}
+}
@@ -0,0 +1,117 @@
+package maputility
+
+import "paintbot-client/models"
+
+// DefaultMaxCollisionRisk is the MaxRisk of a new CollisionPredictor
+const DefaultMaxCollisionRisk = 0.2
+
+// ActionDistribution holds the probability of every action a player may take next tick
+type ActionDistribution map[models.Action]float64
+
+// ActionModel predicts the distribution of the next action of a player
+type ActionModel func(u *MapUtility, p Player) ActionDistribution
+
+// UniformActionModel assumes a player is equally likely to take any of its legal actions.
+// Stunned players always stay.
+func UniformActionModel(u *MapUtility, p Player) ActionDistribution {
+	actions := u.LegalActions(p.GetID())
+	d := make(ActionDistribution, len(actions))
+	for _, a := range actions {
+		d[a] = 1 / float64(len(actions))
+	}
+	return d
+}
+
+// CollisionPredictor estimates where the opponents of a player will be after the next tick,
+// to avoid moving into the same tile as them and getting stunned
+type CollisionPredictor struct {
+	utility  *MapUtility
+	playerID string
+	// Model predicts the actions of the opponents, UniformActionModel if nil
+	Model ActionModel
+	// MaxRisk is the highest probability of a collision that SafeActions accepts
+	MaxRisk float64
+}
+
+// NewCollisionPredictor returns a predictor of collisions between the player and its opponents
+func NewCollisionPredictor(u *MapUtility, playerID string) *CollisionPredictor {
+	return &CollisionPredictor{
+		utility:  u,
+		playerID: playerID,
+		MaxRisk:  DefaultMaxCollisionRisk,
+	}
+}
+
+// ContestProbability returns the probability that any opponent ends the next tick on the tile
+func (c *CollisionPredictor) ContestProbability(coord models.Coordinates) float64 {
+	u := c.utility
+	if u.IsCoordinatesOutOfBounds(coord) {
+		return 0
+	}
+	model := c.Model
+	if model == nil {
+		model = UniformActionModel
+	}
+
+	free := 1.0
+	for _, o := range u.Players() {
+		if o.GetID() == c.playerID {
+			continue
+		}
+		pos := o.GetPos()
+		if pos.ManhattanDistance(coord) > 1 {
+			continue
+		}
+
+		there := 0.0
+		for a, p := range model(u, o) {
+			target, ok := pos.Translate(a)
+			if !ok || !u.IsTileAvailableForMovementTo(target) {
+				target = pos
+			}
+			if target == coord {
+				there += p
+			}
+		}
+		free *= 1 - there
+	}
+	return 1 - free
+}
+
+// Risks returns the probability of a collision for every movement of the player onto a walkable tile
+func (c *CollisionPredictor) Risks() map[models.Action]float64 {
+	risks := map[models.Action]float64{}
+	me, ok := c.utility.GetPlayer(c.playerID)
+	if !ok {
+		return risks
+	}
+	for _, a := range models.Movements {
+		target, _ := me.GetPos().Translate(a)
+		if c.utility.IsTileAvailableForMovementTo(target) {
+			risks[a] = c.ContestProbability(target)
+		}
+	}
+	return risks
+}
+
+// SafeActions returns the candidates that do not risk a collision above MaxRisk.
+// Actions that do not move the player are always safe.
+func (c *CollisionPredictor) SafeActions(candidates []models.Action) []models.Action {
+	me, ok := c.utility.GetPlayer(c.playerID)
+	if !ok {
+		return nil
+	}
+
+	var safe []models.Action
+	for _, a := range candidates {
+		if !a.IsMovement() {
+			safe = append(safe, a)
+			continue
+		}
+		target, _ := me.GetPos().Translate(a)
+		if c.ContestProbability(target) <= c.MaxRisk {
+			safe = append(safe, a)
+		}
+	}
+	return safe
+}
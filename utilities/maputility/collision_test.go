@@ -0,0 +1,48 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestCollisionPredictor(t *testing.T) {
+	// 0 1 2
+	// 3 4 5
+	// 6 7 8
+	mu := New(models.Map{
+		Width:  3,
+		Height: 3,
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0},
+			{ID: "other", Position: 2},
+			{ID: "stunned", Position: 6, StunnedForGameTicks: 2},
+		},
+	}, nil, "myId")
+
+	c := NewCollisionPredictor(mu, "myId")
+
+	// other may stay, go left or go down
+	assert.InDelta(t, 1.0/3, c.ContestProbability(models.Coordinates{X: 1, Y: 0}), 1e-9)
+	assert.Equal(t, 0.0, c.ContestProbability(models.Coordinates{X: 0, Y: 1}))
+	// the stunned player stays where it is
+	assert.Equal(t, 1.0, c.ContestProbability(models.Coordinates{X: 0, Y: 2}))
+
+	risks := c.Risks()
+	assert.Len(t, risks, 2)
+	assert.InDelta(t, 1.0/3, risks[models.Right], 1e-9)
+
+	safe := c.SafeActions([]models.Action{models.Right, models.Down, models.Stay})
+	assert.Equal(t, []models.Action{models.Down, models.Stay}, safe)
+
+	c.MaxRisk = 0.5
+	safe = c.SafeActions([]models.Action{models.Right, models.Down})
+	assert.Equal(t, []models.Action{models.Right, models.Down}, safe)
+
+	c.Model = func(u *MapUtility, p Player) ActionDistribution {
+		return ActionDistribution{models.Stay: 1}
+	}
+	assert.Equal(t, 0.0, c.ContestProbability(models.Coordinates{X: 1, Y: 0}))
+}
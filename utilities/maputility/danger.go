@@ -0,0 +1,111 @@
+package maputility
+
+import (
+	"math"
+
+	"paintbot-client/models"
+)
+
+// DangerMap scores every tile by the risk of it being hit by an opponent explosion
+// within a number of ticks
+type DangerMap struct {
+	utility  *MapUtility
+	score    []float64
+	earliest []int
+}
+
+// DangerMap returns the risk of every tile being hit by an explosion of any opponent of the
+// given player within the next ticks. Every opponent carrying a power-up is assumed to pick
+// any tile it can reach in time, without passing other players, with the same probability
+// and explode there. Tiles only a stunned opponent could hit after the ticks have passed are safe.
+func (u *MapUtility) DangerMap(playerID string, ticks int) *DangerMap {
+	g := u.tiles()
+	d := &DangerMap{
+		utility:  u,
+		score:    make([]float64, len(g.tiles)),
+		earliest: make([]int, len(g.tiles)),
+	}
+	safe := make([]float64, len(g.tiles))
+	for i := range safe {
+		safe[i] = 1
+		d.earliest[i] = Unreachable
+	}
+
+	hits := make([]int, len(g.tiles))
+	for _, o := range u.Players() {
+		if o.GetID() == playerID || !o.HasPowerUp() {
+			continue
+		}
+		// exploding takes a tick of its own
+		maxMoves := ticks - o.StunnedForTicks() - 1
+		if maxMoves < 0 {
+			continue
+		}
+
+		others := u.OpponentCoordinatesOf(o.GetID())
+		field := u.DistanceFieldAvoiding(o.GetPos(), others)
+
+		for i := range hits {
+			hits[i] = 0
+		}
+		centres := 0
+		for _, pos := range field.Reachable() {
+			moves := field.Distance(pos)
+			if moves > maxMoves {
+				continue
+			}
+			centres++
+			tick := o.StunnedForTicks() + moves + 1
+			for _, c := range u.ExplosionArea(u.ConvertPositionToCoordinates(pos)) {
+				hit := u.ConvertCoordinatesToPosition(c)
+				hits[hit]++
+				if d.earliest[hit] == Unreachable || tick < d.earliest[hit] {
+					d.earliest[hit] = tick
+				}
+			}
+		}
+
+		// e.g. the opponent is not on the map, it can't explode anywhere
+		if centres == 0 {
+			continue
+		}
+		for i := range hits {
+			safe[i] *= 1 - float64(hits[i])/float64(centres)
+		}
+	}
+
+	for i := range safe {
+		d.score[i] = 1 - safe[i]
+	}
+	return d
+}
+
+// Score returns the estimated probability, between 0 and 1, that the tile is hit by an explosion
+func (d *DangerMap) Score(c models.Coordinates) float64 {
+	if d.utility.IsCoordinatesOutOfBounds(c) {
+		return 0
+	}
+	return d.score[d.utility.ConvertCoordinatesToPosition(c)]
+}
+
+// EarliestHit returns the first tick, counted from now, at which an explosion could hit the tile
+// and false if no explosion can hit it within the ticks of the map
+func (d *DangerMap) EarliestHit(c models.Coordinates) (int, bool) {
+	if d.utility.IsCoordinatesOutOfBounds(c) {
+		return Unreachable, false
+	}
+	tick := d.earliest[d.utility.ConvertCoordinatesToPosition(c)]
+	return tick, tick != Unreachable
+}
+
+// IsSafe returns true if no opponent explosion can hit the tile
+func (d *DangerMap) IsSafe(c models.Coordinates) bool {
+	return d.Score(c) == 0
+}
+
+// Cost returns a CostFunc for FindPath making moves onto dangerous tiles cost up to penalty more
+func (d *DangerMap) Cost(penalty int) CostFunc {
+	return func(from, to models.Coordinates) int {
+		return 1 + int(math.Round(d.Score(to)*float64(penalty)))
+	}
+}
@@ -0,0 +1,64 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_DangerMap(t *testing.T) {
+	// 0 1 2 3 4 5 6 7 8 9
+	mu := New(models.Map{
+		Width:  10,
+		Height: 1,
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 9},
+			{ID: "bomber", Position: 0, CarryingPowerUp: true},
+			{ID: "harmless", Position: 5},
+		},
+	}, nil, "myId")
+	settings := models.DefaultGameSettings()
+	settings.ExplosionRange = 1
+	mu.SetGameSettings(settings)
+
+	danger := mu.DangerMap("myId", 2)
+
+	// the bomber can explode at 0 next tick, or at 1 the tick after
+	tick, ok := danger.EarliestHit(models.Coordinates{X: 0, Y: 0})
+	assert.True(t, ok)
+	assert.Equal(t, 1, tick)
+	tick, ok = danger.EarliestHit(models.Coordinates{X: 2, Y: 0})
+	assert.True(t, ok)
+	assert.Equal(t, 2, tick)
+
+	assert.Equal(t, 1.0, danger.Score(models.Coordinates{X: 1, Y: 0}))
+	assert.Equal(t, 0.5, danger.Score(models.Coordinates{X: 2, Y: 0}))
+	assert.True(t, danger.IsSafe(models.Coordinates{X: 3, Y: 0}))
+	assert.True(t, danger.IsSafe(models.Coordinates{X: 9, Y: 0}))
+
+	assert.Equal(t, 1, danger.Cost(10)(models.Coordinates{}, models.Coordinates{X: 3, Y: 0}))
+	assert.Equal(t, 6, danger.Cost(10)(models.Coordinates{}, models.Coordinates{X: 2, Y: 0}))
+
+	// the bombers own explosions are no danger to itself
+	assert.True(t, mu.DangerMap("bomber", 2).IsSafe(models.Coordinates{X: 1, Y: 0}))
+}
+
+func TestMapUtility_DangerMapIgnoresOpponentsOffTheMap(t *testing.T) {
+	mu := New(models.Map{
+		Width:  10,
+		Height: 1,
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 9},
+			{ID: "bomber", Position: -1, CarryingPowerUp: true},
+		},
+	}, nil, "myId")
+
+	danger := mu.DangerMap("myId", 2)
+
+	for x := 0; x < 10; x++ {
+		assert.Equal(t, 0.0, danger.Score(models.Coordinates{X: x, Y: 0}))
+		assert.True(t, danger.IsSafe(models.Coordinates{X: x, Y: 0}))
+	}
+}
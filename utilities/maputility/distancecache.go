@@ -0,0 +1,175 @@
+package maputility
+
+import (
+	"container/list"
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"paintbot-client/models"
+)
+
+// DefaultDistanceCacheBytes is enough to hold the distances between all pairs
+// of tiles of a full size map
+const DefaultDistanceCacheBytes = 64 << 20
+
+// LayoutHash returns a hash of the size and the obstacle positions of the map.
+// Obstacles never move during a game, so maps with the same layout hash have
+// the same distances between all tiles when players are not considered.
+func LayoutHash(m models.Map) uint64 {
+	obstacles := append([]int(nil), m.ObstacleUpPositions...)
+	sort.Ints(obstacles)
+
+	h := fnv.New64a()
+	var buf [8]byte
+	write := func(v int) {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	}
+	write(m.Width)
+	write(m.Height)
+	for _, o := range obstacles {
+		write(o)
+	}
+	return h.Sum64()
+}
+
+// DistanceCache keeps distance fields keyed by the layout of the map and the source,
+// so they can be reused across ticks and consecutive games on the same map.
+// Fields are computed on demand and the least recently used fields are evicted
+// once the cache grows beyond its size limit. It is safe for concurrent use.
+type DistanceCache struct {
+	mu       sync.Mutex
+	maxBytes int
+	bytes    int
+	lru      *list.List
+	entries  map[distanceKey]*list.Element
+}
+
+type distanceKey struct {
+	layout uint64
+	source int
+}
+
+type distanceEntry struct {
+	key   distanceKey
+	field *DistanceField
+}
+
+// NewDistanceCache returns a cache holding at most maxBytes of distance fields
+func NewDistanceCache(maxBytes int) *DistanceCache {
+	return &DistanceCache{
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  map[distanceKey]*list.Element{},
+	}
+}
+
+// Field returns the distance field from the source over the map of the utility,
+// computing it if it is not cached. Players are not considered blocking.
+func (c *DistanceCache) Field(u *MapUtility, source models.Coordinates) *DistanceField {
+	return c.field(u.LayoutHash(), u.tiles(), u.sourcePosition(source))
+}
+
+// Distance returns the distance between two coordinates on the map of the utility
+// and false if there is no path between them
+func (c *DistanceCache) Distance(u *MapUtility, from, to models.Coordinates) (int, bool) {
+	return c.Field(u, from).DistanceTo(to)
+}
+
+// Precompute fills the cache with the distance fields of every walkable tile of the map,
+// as far as the size limit allows
+func (c *DistanceCache) Precompute(u *MapUtility) {
+	layout := u.LayoutHash()
+	g := u.tiles()
+	for pos := range g.tiles {
+		if g.walkable(pos) {
+			c.field(layout, g, pos)
+		}
+	}
+}
+
+// Graph returns a Graph of the map of the utility backed by the cache, for use with SetGraph
+func (c *DistanceCache) Graph(u *MapUtility) Graph {
+	return &cachedGraph{cache: c, layout: u.LayoutHash(), grid: u.tiles()}
+}
+
+// Len returns the number of cached distance fields
+func (c *DistanceCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Bytes returns the approximate memory used by the cached distance fields
+func (c *DistanceCache) Bytes() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bytes
+}
+
+func (c *DistanceCache) field(layout uint64, g *tileGrid, source int) *DistanceField {
+	// Nothing is reachable from obstacles or outside the map, don't spend the memory budget on them
+	if !g.inRange(source) || !g.walkable(source) {
+		return bfs(g, source, nil)
+	}
+	key := distanceKey{layout: layout, source: source}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*distanceEntry).field
+	}
+	c.mu.Unlock()
+
+	// Computed without holding the lock, two callers may compute the same field
+	f := bfs(g, source, nil)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		return e.Value.(*distanceEntry).field
+	}
+	c.entries[key] = c.lru.PushFront(&distanceEntry{key: key, field: f})
+	c.bytes += f.size()
+	for c.bytes > c.maxBytes && c.lru.Len() > 1 {
+		oldest := c.lru.Back()
+		entry := c.lru.Remove(oldest).(*distanceEntry)
+		delete(c.entries, entry.key)
+		c.bytes -= entry.field.size()
+	}
+	return f
+}
+
+// size returns the approximate memory used by the field in bytes
+func (f *DistanceField) size() int {
+	const intSize = 8
+	return (len(f.dist) + len(f.parent)) * intSize
+}
+
+// LayoutHash returns the LayoutHash of the map of the utility
+func (u *MapUtility) LayoutHash() uint64 {
+	if u.layout == nil {
+		h := LayoutHash(u.mapp)
+		u.layout = &h
+	}
+	return *u.layout
+}
+
+// cachedGraph is a Graph looking up distance fields in a DistanceCache
+type cachedGraph struct {
+	cache  *DistanceCache
+	layout uint64
+	grid   *tileGrid
+}
+
+func (g *cachedGraph) Shortest(from int, to int) (BestPath, error) {
+	path := g.cache.field(g.layout, g.grid, from).Path(to)
+	if path == nil {
+		return BestPath{}, ErrNoPath
+	}
+	return BestPath{Distance: int64(len(path) - 1), Path: path}, nil
+}
@@ -0,0 +1,97 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestLayoutHash_ignoresEverythingButObstacles(t *testing.T) {
+	a := models.Map{Width: 5, Height: 5, ObstacleUpPositions: []int{3, 7}, PowerUpPositions: []int{1}}
+	b := models.Map{Width: 5, Height: 5, ObstacleUpPositions: []int{7, 3}, WorldTick: 10}
+	c := models.Map{Width: 5, Height: 5, ObstacleUpPositions: []int{3, 8}}
+
+	assert.Equal(t, LayoutHash(a), LayoutHash(b))
+	assert.NotEqual(t, LayoutHash(a), LayoutHash(c))
+}
+
+func TestDistanceCache_reusesFieldsAcrossMapsWithTheSameLayout(t *testing.T) {
+	cache := NewDistanceCache(DefaultDistanceCacheBytes)
+	first := New(models.Map{Width: 5, Height: 5, ObstacleUpPositions: []int{6}}, nil, "")
+	second := New(models.Map{Width: 5, Height: 5, ObstacleUpPositions: []int{6}, WorldTick: 1}, nil, "")
+
+	from := models.Coordinates{X: 0, Y: 0}
+	d, ok := cache.Distance(first, from, models.Coordinates{X: 2, Y: 2})
+	assert.True(t, ok)
+	assert.Equal(t, 4, d)
+
+	assert.Same(t, cache.Field(first, from), cache.Field(second, from))
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestDistanceCache_evictsLeastRecentlyUsed(t *testing.T) {
+	mu := New(models.Map{Width: 5, Height: 5}, nil, "")
+	fieldSize := mu.DistanceFieldFrom(models.Coordinates{}).size()
+	cache := NewDistanceCache(2 * fieldSize)
+
+	a := cache.Field(mu, models.Coordinates{X: 0, Y: 0})
+	cache.Field(mu, models.Coordinates{X: 1, Y: 0})
+	cache.Field(mu, models.Coordinates{X: 0, Y: 0})
+	cache.Field(mu, models.Coordinates{X: 2, Y: 0})
+
+	assert.Equal(t, 2, cache.Len())
+	assert.LessOrEqual(t, cache.Bytes(), 2*fieldSize)
+	assert.Same(t, a, cache.Field(mu, models.Coordinates{X: 0, Y: 0}))
+}
+
+func TestDistanceCache_doesNotCacheObstacleSources(t *testing.T) {
+	cache := NewDistanceCache(DefaultDistanceCacheBytes)
+	mu := New(models.Map{Width: 5, Height: 5, ObstacleUpPositions: []int{6}}, nil, "")
+
+	cache.Field(mu, models.Coordinates{X: 1, Y: 1})
+	_, ok := cache.Distance(mu, models.Coordinates{X: -1, Y: 0}, models.Coordinates{X: 0, Y: 0})
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestDistanceCache_Graph(t *testing.T) {
+	mu := New(models.Map{
+		Width:          5,
+		Height:         5,
+		CharacterInfos: []models.CharacterInfo{{ID: "myId", Position: 0}},
+	}, nil, "myId")
+	mu.SetGraph(NewDistanceCache(DefaultDistanceCacheBytes).Graph(mu))
+
+	d, err := mu.DistanceTo(models.Coordinates{X: 4, Y: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 8, d)
+}
+
+func BenchmarkDistanceCache_Distance(b *testing.B) {
+	mu := New(fullSizeMap(), nil, "a")
+	cache := NewDistanceCache(DefaultDistanceCacheBytes)
+	cache.Precompute(mu)
+	// only walkable sources are precomputed, anything else would measure a BFS
+	var sources []models.Coordinates
+	for pos := range mu.tiles().tiles {
+		if mu.tiles().walkable(pos) {
+			sources = append(sources, mu.ConvertPositionToCoordinates(pos))
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		from := sources[i%len(sources)]
+		to := models.Coordinates{X: (i * 7) % fullMapWidth, Y: (i * 3) % fullMapHeight}
+		cache.Distance(mu, from, to)
+	}
+}
+
+func BenchmarkDistanceCache_Precompute(b *testing.B) {
+	mu := New(fullSizeMap(), nil, "a")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewDistanceCache(DefaultDistanceCacheBytes).Precompute(mu)
+	}
+}
@@ -0,0 +1,108 @@
+package maputility
+
+import "paintbot-client/models"
+
+// Explosion is the outcome of a player exploding a power-up at Center,
+// evaluated against the current state of the map
+type Explosion struct {
+	PlayerID string
+	Center   models.Coordinates
+	// Area holds every walkable tile reached by the explosion
+	Area []models.Coordinates
+	// Recoloured holds the tiles of the area not already coloured by the exploding player
+	Recoloured []models.Coordinates
+	// TakenFrom holds the number of recoloured tiles by the ID of the player that had coloured them
+	TakenFrom map[string]int
+	// Stunned holds the opponents within the area that will be stunned
+	Stunned []Player
+	// Invulnerable holds the opponents within the area that cannot be stunned,
+	// recent stuns are only known if a GameTracker is set
+	Invulnerable []Player
+	// PointsGained by the exploding player for recoloured tiles and caused stuns
+	PointsGained int
+	// OpponentPointsLost by all opponents for the tiles taken from them
+	OpponentPointsLost int
+}
+
+// NetPointSwing returns the change in the point difference between the exploding player and its opponents
+func (e Explosion) NetPointSwing() int {
+	return e.PointsGained + e.OpponentPointsLost
+}
+
+// SetGameSettings sets the settings of the game, used by the explosion model.
+// The server defaults are used if no settings are set.
+func (u *MapUtility) SetGameSettings(s models.GameSettings) {
+	u.settings = &s
+}
+
+// GetGameSettings returns the settings set with SetGameSettings, or the server defaults
+func (u *MapUtility) GetGameSettings() models.GameSettings {
+	if u.settings == nil {
+		return models.DefaultGameSettings()
+	}
+	return *u.settings
+}
+
+// ExplosionArea returns the tiles that an explosion at the center would reach:
+// every tile on the map that is not an obstacle within ExplosionRange moves of the center,
+// as the crow flies
+func (u *MapUtility) ExplosionArea(center models.Coordinates) []models.Coordinates {
+	blast := models.Diamond{Center: center, Radius: u.GetGameSettings().ExplosionRange}
+
+	var area []models.Coordinates
+	for _, c := range models.Clip(blast, models.MapBounds(u.mapp.Width, u.mapp.Height)) {
+		if u.GetTileAt(c) != models.Obstacle {
+			area = append(area, c)
+		}
+	}
+	return area
+}
+
+// ExplosionAt returns the outcome of the given player exploding a power-up at the center.
+// Returns false if the player is not on the map.
+func (u *MapUtility) ExplosionAt(playerID string, center models.Coordinates) (Explosion, bool) {
+	exploder, ok := u.playerIndex()[playerID]
+	if !ok {
+		return Explosion{}, false
+	}
+
+	settings := u.GetGameSettings()
+	g := u.tiles()
+	e := Explosion{
+		PlayerID:  playerID,
+		Center:    center,
+		Area:      u.ExplosionArea(center),
+		TakenFrom: map[string]int{},
+	}
+
+	for _, c := range e.Area {
+		pos := u.ConvertCoordinatesToPosition(c)
+		if owner := g.owner(pos); owner != exploder {
+			e.Recoloured = append(e.Recoloured, c)
+			if owner != noPlayer {
+				e.TakenFrom[u.mapp.CharacterInfos[owner].ID]++
+			}
+		}
+
+		if occupant := g.occupant(pos); occupant != noPlayer && occupant != exploder {
+			p := u.playerAtIndex(occupant)
+			if u.isImmuneToStun(p) {
+				e.Invulnerable = append(e.Invulnerable, p)
+			} else {
+				e.Stunned = append(e.Stunned, p)
+			}
+		}
+	}
+
+	e.PointsGained = len(e.Recoloured)*settings.PointsPerTileOwned + len(e.Stunned)*settings.PointsPerCausedStun
+	for _, taken := range e.TakenFrom {
+		e.OpponentPointsLost += taken * settings.PointsPerTileOwned
+	}
+	return e, true
+}
+
+// isImmuneToStun returns true if an explosion cannot stun the player,
+// because it is already stunned or recently was
+func (u *MapUtility) isImmuneToStun(p Player) bool {
+	return p.StunnedForTicks() > 0 || p.IsInvulnerable()
+}
@@ -0,0 +1,53 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_ExplosionAt(t *testing.T) {
+	// 0  1  2  3  4
+	// 5  #  7  8  9
+	// 10 11 12 13 14
+	mu := New(models.Map{
+		Width:               5,
+		Height:              3,
+		ObstacleUpPositions: []int{6},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0, ColouredPosition: []int{0, 1}},
+			{ID: "near", Position: 2, ColouredPosition: []int{2, 5}},
+			{ID: "stunned", Position: 10, StunnedForGameTicks: 3},
+			{ID: "far", Position: 14},
+		},
+	}, nil, "myId")
+	settings := models.DefaultGameSettings()
+	settings.ExplosionRange = 2
+	mu.SetGameSettings(settings)
+
+	e, ok := mu.ExplosionAt("myId", models.Coordinates{X: 0, Y: 0})
+	assert.True(t, ok)
+	// {0,0} {1,0} {2,0} {0,1} {0,2}, the obstacle at {1,1} is excluded
+	assert.Len(t, e.Area, 5)
+	assert.Len(t, e.Recoloured, 3)
+	assert.Equal(t, map[string]int{"near": 2}, e.TakenFrom)
+	if assert.Len(t, e.Stunned, 1) {
+		assert.Equal(t, "near", e.Stunned[0].GetID())
+	}
+	if assert.Len(t, e.Invulnerable, 1) {
+		assert.Equal(t, "stunned", e.Invulnerable[0].GetID())
+	}
+	assert.Equal(t, 3*settings.PointsPerTileOwned+settings.PointsPerCausedStun, e.PointsGained)
+	assert.Equal(t, 2*settings.PointsPerTileOwned, e.OpponentPointsLost)
+	assert.Equal(t, e.PointsGained+e.OpponentPointsLost, e.NetPointSwing())
+
+	_, ok = mu.ExplosionAt("missing", models.Coordinates{})
+	assert.False(t, ok)
+	assert.True(t, mu.IsAnyPlayerWithinExplosionRange())
+
+	settings.ExplosionRange = 1
+	mu.SetGameSettings(settings)
+	assert.False(t, mu.IsAnyPlayerWithinExplosionRange())
+}
@@ -0,0 +1,101 @@
+package maputility
+
+import "sort"
+
+// ExplosionPlan is to move along Path and then explode the carried power-up at its end.
+// Opponents are assumed to stay where they are while the path is followed.
+type ExplosionPlan struct {
+	Path      Path
+	Explosion Explosion
+	// Ticks until the power-up can be exploded, waiting out any stun and following the path
+	Ticks int
+	// TilesGained counts the tiles coloured by following the path and exploding
+	TilesGained int
+	// Stuns counts the opponents stunned by the explosion
+	Stuns int
+	// Points is the change in the point difference to the opponents caused by the plan
+	Points int
+}
+
+// PlanExplosions evaluates exploding the power-up carried by the player now and after moving to
+// every tile reachable within maxMoves moves, without passing other players.
+// The plans are ranked best first by points, stuns, tiles gained and finally the fewest ticks.
+// Returns nil if the player is not on the map or does not carry a power-up.
+func (u *MapUtility) PlanExplosions(playerID string, maxMoves int) []ExplosionPlan {
+	player, ok := u.GetPlayer(playerID)
+	if !ok || !player.HasPowerUp() {
+		return nil
+	}
+
+	others := u.OpponentCoordinatesOf(playerID)
+	field := u.DistanceFieldAvoiding(player.GetPos(), others)
+
+	var plans []ExplosionPlan
+	for _, pos := range field.Reachable() {
+		if field.Distance(pos) > maxMoves {
+			continue
+		}
+		plans = append(plans, u.planExplosion(player, u.ConvertPositionsToPath(field.Path(pos))))
+	}
+
+	sort.SliceStable(plans, func(i, j int) bool {
+		a, b := plans[i], plans[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.Stuns != b.Stuns {
+			return a.Stuns > b.Stuns
+		}
+		if a.TilesGained != b.TilesGained {
+			return a.TilesGained > b.TilesGained
+		}
+		return a.Ticks < b.Ticks
+	})
+	return plans
+}
+
+// BestExplosionPlan returns the highest ranked plan of PlanExplosions.
+// Returns false if the player is not on the map or does not carry a power-up.
+func (u *MapUtility) BestExplosionPlan(playerID string, maxMoves int) (ExplosionPlan, bool) {
+	plans := u.PlanExplosions(playerID, maxMoves)
+	if len(plans) == 0 {
+		return ExplosionPlan{}, false
+	}
+	return plans[0], true
+}
+
+func (u *MapUtility) planExplosion(player Player, path Path) ExplosionPlan {
+	settings := u.GetGameSettings()
+	g := u.tiles()
+	me := u.playerIndex()[player.GetID()]
+
+	center, _ := path.End()
+	explosion, _ := u.ExplosionAt(player.GetID(), center)
+
+	gained := map[int]bool{}
+	for _, pos := range path.entered() {
+		if g.owner(pos) != me {
+			gained[pos] = true
+		}
+	}
+	for _, c := range explosion.Recoloured {
+		gained[u.ConvertCoordinatesToPosition(c)] = true
+	}
+
+	taken := 0
+	for pos := range gained {
+		if g.owner(pos) != noPlayer {
+			taken++
+		}
+	}
+
+	return ExplosionPlan{
+		Path:        path,
+		Explosion:   explosion,
+		Ticks:       player.StunnedForTicks() + path.Len(),
+		TilesGained: len(gained),
+		Stuns:       len(explosion.Stunned),
+		Points: (len(gained)+taken)*settings.PointsPerTileOwned +
+			len(explosion.Stunned)*settings.PointsPerCausedStun,
+	}
+}
@@ -0,0 +1,46 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_PlanExplosions(t *testing.T) {
+	// 0  1  2  3  4  5  6
+	mu := New(models.Map{
+		Width:  7,
+		Height: 1,
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0, CarryingPowerUp: true, ColouredPosition: []int{0, 1, 2}},
+			{ID: "other", Position: 6},
+		},
+	}, nil, "myId")
+	settings := models.DefaultGameSettings()
+	settings.ExplosionRange = 1
+	mu.SetGameSettings(settings)
+
+	plans := mu.PlanExplosions("myId", 10)
+	// every tile but the one of the other player
+	assert.Len(t, plans, 6)
+
+	best := plans[0]
+	end, _ := best.Path.End()
+	assert.Equal(t, models.Coordinates{X: 5, Y: 0}, end)
+	assert.Equal(t, 1, best.Stuns)
+	assert.Equal(t, 5, best.Ticks)
+	// tiles 3, 4, 5 along the path and 6 from the explosion
+	assert.Equal(t, 4, best.TilesGained)
+	assert.Equal(t, 4*settings.PointsPerTileOwned+settings.PointsPerCausedStun, best.Points)
+
+	plans = mu.PlanExplosions("myId", 0)
+	if assert.Len(t, plans, 1) {
+		assert.Equal(t, 0, plans[0].Path.Len())
+		assert.Equal(t, 0, plans[0].TilesGained)
+	}
+
+	_, ok := mu.BestExplosionPlan("other", 3)
+	assert.False(t, ok)
+}
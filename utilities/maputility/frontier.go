@@ -0,0 +1,200 @@
+package maputility
+
+import (
+	"sort"
+
+	"paintbot-client/models"
+)
+
+// TileCluster is a group of tiles connected by single movements
+type TileCluster struct {
+	Tiles []models.Coordinates
+}
+
+// Size returns the number of tiles in the cluster
+func (c TileCluster) Size() int {
+	return len(c.Tiles)
+}
+
+// BorderSegment is a connected group of tiles where two players' coloured areas meet.
+// It holds the tiles on both sides of the border.
+type BorderSegment struct {
+	PlayerIDs [2]string
+	TileCluster
+}
+
+// Frontier returns the walkable tiles not coloured by the player next to a tile coloured by the player
+func (u *MapUtility) Frontier(playerID string) []models.Coordinates {
+	return u.ConvertPositionsToCoordinates(u.frontierPositions(playerID))
+}
+
+// FrontierClusters returns the connected groups of Frontier tiles, largest first
+func (u *MapUtility) FrontierClusters(playerID string) []TileCluster {
+	frontier := map[int]bool{}
+	for _, pos := range u.frontierPositions(playerID) {
+		frontier[pos] = true
+	}
+	clusters, _ := u.clusters(func(pos int) bool { return frontier[pos] })
+	return clusters
+}
+
+// Borders returns the segments where the coloured areas of two players meet, largest first
+func (u *MapUtility) Borders() []BorderSegment {
+	g := u.tiles()
+	type pair struct{ a, b int }
+	sides := map[pair]map[int]bool{}
+
+	var neighbours [4]int
+	for pos := range g.tiles {
+		owner := g.owner(pos)
+		if owner == noPlayer {
+			continue
+		}
+		for _, n := range g.neighbours(pos, neighbours[:0]) {
+			other := g.owner(n)
+			if other == noPlayer || other == owner {
+				continue
+			}
+			key := pair{owner, other}
+			if other < owner {
+				key = pair{other, owner}
+			}
+			if sides[key] == nil {
+				sides[key] = map[int]bool{}
+			}
+			sides[key][pos] = true
+			sides[key][n] = true
+		}
+	}
+
+	var segments []BorderSegment
+	for key, tiles := range sides {
+		clusters, _ := u.clusters(func(pos int) bool { return tiles[pos] })
+		for _, c := range clusters {
+			segments = append(segments, BorderSegment{
+				PlayerIDs:   [2]string{u.mapp.CharacterInfos[key.a].ID, u.mapp.CharacterInfos[key.b].ID},
+				TileCluster: c,
+			})
+		}
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		if segments[i].Size() != segments[j].Size() {
+			return segments[i].Size() > segments[j].Size()
+		}
+		a, b := segments[i].PlayerIDs, segments[j].PlayerIDs
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		if a[1] != b[1] {
+			return a[1] < b[1]
+		}
+		return u.ConvertCoordinatesToPosition(segments[i].Tiles[0]) < u.ConvertCoordinatesToPosition(segments[j].Tiles[0])
+	})
+	return segments
+}
+
+// UnpaintedRegions returns the connected groups of walkable tiles not coloured by any player, largest first
+func (u *MapUtility) UnpaintedRegions() []TileCluster {
+	clusters, _ := u.clusters(u.isUnpaintedPosition)
+	return clusters
+}
+
+// NearestUnpaintedRegion returns the unpainted region closest to the given coordinates
+// together with the number of moves to its nearest tile. Players are not considered blocking.
+// Returns false if no unpainted tile can be reached.
+func (u *MapUtility) NearestUnpaintedRegion(from models.Coordinates) (TileCluster, int, bool) {
+	clusters, labels := u.clusters(u.isUnpaintedPosition)
+	field := u.DistanceFieldFrom(from)
+
+	nearest, best := Unreachable, 0
+	for _, pos := range field.Reachable() {
+		d := field.Distance(pos)
+		if labels[pos] != Unreachable && (nearest == Unreachable || d < best) {
+			nearest, best = pos, d
+		}
+	}
+	if nearest == Unreachable {
+		return TileCluster{}, Unreachable, false
+	}
+	return clusters[labels[nearest]], best, true
+}
+
+func (u *MapUtility) isUnpaintedPosition(pos int) bool {
+	g := u.tiles()
+	return g.walkable(pos) && g.owner(pos) == noPlayer
+}
+
+func (u *MapUtility) frontierPositions(playerID string) []int {
+	me, ok := u.playerIndex()[playerID]
+	if !ok {
+		return nil
+	}
+	g := u.tiles()
+
+	var frontier []int
+	var neighbours [4]int
+	for pos := range g.tiles {
+		if !g.walkable(pos) || g.owner(pos) == me {
+			continue
+		}
+		for _, n := range g.neighbours(pos, neighbours[:0]) {
+			if g.owner(n) == me {
+				frontier = append(frontier, pos)
+				break
+			}
+		}
+	}
+	return frontier
+}
+
+// clusters returns the connected groups of positions that are members, largest first,
+// and the index in the returned clusters of every position, Unreachable for non members
+func (u *MapUtility) clusters(member func(pos int) bool) ([]TileCluster, []int) {
+	g := u.tiles()
+	labels := make([]int, len(g.tiles))
+	for i := range labels {
+		labels[i] = Unreachable
+	}
+
+	var groups [][]int
+	var neighbours [4]int
+	for start := range g.tiles {
+		if labels[start] != Unreachable || !member(start) {
+			continue
+		}
+		label := len(groups)
+		labels[start] = label
+		group := []int{start}
+		for head := 0; head < len(group); head++ {
+			for _, n := range g.neighbours(group[head], neighbours[:0]) {
+				if labels[n] == Unreachable && member(n) {
+					labels[n] = label
+					group = append(group, n)
+				}
+			}
+		}
+		sort.Ints(group)
+		groups = append(groups, group)
+	}
+
+	// order largest first and relabel accordingly
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(groups[order[i]]) > len(groups[order[j]])
+	})
+	relabel := make([]int, len(groups))
+	clusters := make([]TileCluster, len(groups))
+	for i, g := range order {
+		relabel[g] = i
+		clusters[i] = TileCluster{Tiles: u.ConvertPositionsToCoordinates(groups[g])}
+	}
+	for pos, label := range labels {
+		if label != Unreachable {
+			labels[pos] = relabel[label]
+		}
+	}
+	return clusters, labels
+}
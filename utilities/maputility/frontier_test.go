@@ -0,0 +1,49 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_Frontier(t *testing.T) {
+	// a a . # .
+	// a b b # .
+	mu := New(models.Map{
+		Width:               5,
+		Height:              2,
+		ObstacleUpPositions: []int{3, 8},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "a", Position: 0, ColouredPosition: []int{0, 1, 5}},
+			{ID: "b", Position: 6, ColouredPosition: []int{6, 7}},
+		},
+	}, nil, "a")
+
+	assert.Equal(t, []models.Coordinates{{X: 2, Y: 0}, {X: 1, Y: 1}}, mu.Frontier("a"))
+
+	clusters := mu.FrontierClusters("b")
+	if assert.Len(t, clusters, 2) {
+		// {1,0} {2,0} and {0,1}
+		assert.Equal(t, 2, clusters[0].Size())
+		assert.Equal(t, 1, clusters[1].Size())
+	}
+
+	borders := mu.Borders()
+	if assert.Len(t, borders, 1) {
+		assert.Equal(t, [2]string{"a", "b"}, borders[0].PlayerIDs)
+		assert.ElementsMatch(t, []models.Coordinates{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}, borders[0].Tiles)
+	}
+
+	regions := mu.UnpaintedRegions()
+	if assert.Len(t, regions, 2) {
+		assert.Equal(t, 2, regions[0].Size())
+		assert.Equal(t, []models.Coordinates{{X: 2, Y: 0}}, regions[1].Tiles)
+	}
+
+	region, d, ok := mu.NearestUnpaintedRegion(models.Coordinates{X: 0, Y: 0})
+	assert.True(t, ok)
+	assert.Equal(t, 2, d)
+	assert.Equal(t, 1, region.Size())
+}
@@ -0,0 +1,112 @@
+package maputility
+
+import "paintbot-client/models"
+
+const noPlayer = -1
+
+// tileGrid is a dense index of the map, built once per map update,
+// making lookups of a single tile constant time
+type tileGrid struct {
+	width  int
+	height int
+	// tiles holds the type of object at every position
+	tiles []models.Tile
+	// owners holds the index in CharacterInfos of the player that coloured every position
+	owners []int
+	// occupants holds the index in CharacterInfos of the player standing on every position
+	occupants []int
+	// owned holds the number of positions coloured by every player, by index in CharacterInfos
+	owned []int
+	// unpainted is the number of walkable positions not coloured by any player
+	unpainted int
+}
+
+func newTileGrid(m models.Map) *tileGrid {
+	size := m.Width * m.Height
+	if size < 0 {
+		size = 0
+	}
+	g := &tileGrid{
+		width:     m.Width,
+		height:    m.Height,
+		tiles:     make([]models.Tile, size),
+		owners:    make([]int, size),
+		occupants: make([]int, size),
+		owned:     make([]int, len(m.CharacterInfos)),
+	}
+	for i := 0; i < size; i++ {
+		g.tiles[i] = models.Open
+		g.owners[i] = noPlayer
+		g.occupants[i] = noPlayer
+	}
+
+	// Iterated backwards so the first player listed wins, should two claim the same tile
+	for i := len(m.CharacterInfos) - 1; i >= 0; i-- {
+		for _, pos := range m.CharacterInfos[i].ColouredPosition {
+			if g.inRange(pos) {
+				g.owners[pos] = i
+			}
+		}
+	}
+
+	// Later layers take precedence in the same order as getTileAtPosition used to check them
+	for i, c := range m.CharacterInfos {
+		if g.inRange(c.Position) {
+			g.tiles[c.Position] = models.Player
+			g.occupants[c.Position] = i
+		}
+	}
+	for _, pos := range m.PowerUpPositions {
+		if g.inRange(pos) {
+			g.tiles[pos] = models.PowerUp
+		}
+	}
+	for _, pos := range m.ObstacleUpPositions {
+		if g.inRange(pos) {
+			g.tiles[pos] = models.Obstacle
+		}
+	}
+
+	for pos := range g.tiles {
+		switch {
+		case g.owners[pos] != noPlayer:
+			g.owned[g.owners[pos]]++
+		case g.walkable(pos):
+			g.unpainted++
+		}
+	}
+	return g
+}
+
+func (g *tileGrid) inRange(pos int) bool {
+	return pos >= 0 && pos < len(g.tiles)
+}
+
+// tile returns the type of object at the position, OBSTACLE if it is out of range
+func (g *tileGrid) tile(pos int) models.Tile {
+	if !g.inRange(pos) {
+		return models.Obstacle
+	}
+	return g.tiles[pos]
+}
+
+// owner returns the index of the player that coloured the position or noPlayer
+func (g *tileGrid) owner(pos int) int {
+	if !g.inRange(pos) {
+		return noPlayer
+	}
+	return g.owners[pos]
+}
+
+// occupant returns the index of the player standing on the position or noPlayer
+func (g *tileGrid) occupant(pos int) int {
+	if !g.inRange(pos) {
+		return noPlayer
+	}
+	return g.occupants[pos]
+}
+
+// walkable returns true if a player may ever stand on the position
+func (g *tileGrid) walkable(pos int) bool {
+	return g.inRange(pos) && g.tiles[pos] != models.Obstacle
+}
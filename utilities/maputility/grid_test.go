@@ -0,0 +1,121 @@
+package maputility
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+// dimensions of the maps generated by the server
+const (
+	fullMapWidth  = 46
+	fullMapHeight = 34
+)
+
+// fullSizeMap returns a deterministic map of the size the server uses,
+// with obstacles, power-ups and five players that have painted a part of the map
+func fullSizeMap() models.Map {
+	r := rand.New(rand.NewSource(1))
+	size := fullMapWidth * fullMapHeight
+	taken := map[int]bool{}
+	free := func() int {
+		for {
+			p := r.Intn(size)
+			if !taken[p] {
+				taken[p] = true
+				return p
+			}
+		}
+	}
+
+	m := models.Map{Width: fullMapWidth, Height: fullMapHeight}
+	for i := 0; i < 200; i++ {
+		m.ObstacleUpPositions = append(m.ObstacleUpPositions, free())
+	}
+	for i := 0; i < 40; i++ {
+		m.PowerUpPositions = append(m.PowerUpPositions, free())
+	}
+	for i := 0; i < 5; i++ {
+		m.CharacterInfos = append(m.CharacterInfos, models.CharacterInfo{
+			ID:       string(rune('a' + i)),
+			Name:     "player " + string(rune('a'+i)),
+			Position: free(),
+		})
+	}
+	for p := 0; p < size; p++ {
+		if !taken[p] && r.Intn(2) == 0 {
+			i := r.Intn(len(m.CharacterInfos))
+			m.CharacterInfos[i].ColouredPosition = append(m.CharacterInfos[i].ColouredPosition, p)
+		}
+	}
+	return m
+}
+
+func TestMapUtility_GetTileAt(t *testing.T) {
+	mu := New(models.Map{
+		Width:               3,
+		Height:              3,
+		ObstacleUpPositions: []int{0},
+		PowerUpPositions:    []int{1},
+		CharacterInfos: []models.CharacterInfo{{
+			ID:               "myId",
+			Position:         4,
+			ColouredPosition: []int{4, 5},
+		}},
+	}, nil, "myId")
+
+	assert.Equal(t, models.Obstacle, mu.GetTileAt(models.Coordinates{X: 0, Y: 0}))
+	assert.Equal(t, models.PowerUp, mu.GetTileAt(models.Coordinates{X: 1, Y: 0}))
+	assert.Equal(t, models.Player, mu.GetTileAt(models.Coordinates{X: 1, Y: 1}))
+	assert.Equal(t, models.Open, mu.GetTileAt(models.Coordinates{X: 2, Y: 2}))
+	assert.Equal(t, models.Obstacle, mu.GetTileAt(models.Coordinates{X: 3, Y: 0}))
+
+	owner := mu.GetColouredBy(models.Coordinates{X: 2, Y: 1})
+	if assert.NotNil(t, owner) {
+		assert.Equal(t, "myId", owner.GetID())
+	}
+	assert.Nil(t, mu.GetColouredBy(models.Coordinates{X: 2, Y: 2}))
+}
+
+func BenchmarkNew(b *testing.B) {
+	m := fullSizeMap()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		New(m, nil, "a")
+	}
+}
+
+func BenchmarkMapUtility_GetTileAt(b *testing.B) {
+	mu := New(fullSizeMap(), nil, "a")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mu.GetTileAt(models.Coordinates{X: i % fullMapWidth, Y: (i / fullMapWidth) % fullMapHeight})
+	}
+}
+
+func BenchmarkMapUtility_GetColouredBy(b *testing.B) {
+	mu := New(fullSizeMap(), nil, "a")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mu.GetColouredBy(models.Coordinates{X: i % fullMapWidth, Y: (i / fullMapWidth) % fullMapHeight})
+	}
+}
+
+func BenchmarkGraphOfMap_Shortest(b *testing.B) {
+	mu := New(fullSizeMap(), nil, "a")
+	g := GraphOfMap(*mu)
+	var walkable []int
+	for pos := range mu.tiles().tiles {
+		if mu.tiles().walkable(pos) {
+			walkable = append(walkable, pos)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// a new source every time, so every query searches the map
+		g.Shortest(walkable[i%len(walkable)], walkable[(i*7)%len(walkable)])
+	}
+}
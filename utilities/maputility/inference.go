@@ -0,0 +1,125 @@
+package maputility
+
+import (
+	"paintbot-client/models"
+	"paintbot-client/utilities/arrays"
+)
+
+// ActionOutcome is what happened to a player as a result of its action
+type ActionOutcome string
+
+const (
+	// the player moved to a neighbouring tile
+	Moved ActionOutcome = "MOVED"
+	// the player stayed, or tried to move into an obstacle, the edge of the map or another player
+	Stayed ActionOutcome = "STAYED"
+	// the player exploded its power-up
+	Exploded ActionOutcome = "EXPLODED"
+	// the player tried to move into the same tile as another player and got stunned
+	Collided ActionOutcome = "COLLIDED"
+	// the player got stunned by an explosion, or was already stunned, so its action had no effect
+	Stunned ActionOutcome = "STUNNED"
+)
+
+// InferredAction is the action a player most likely took between two consecutive maps
+type InferredAction struct {
+	PlayerID string
+	Action   models.Action
+	Outcome  ActionOutcome
+	// Confidence is the probability of Action among the Candidates
+	Confidence float64
+	// Candidates holds every action consistent with the change in the map and its probability
+	Candidates ActionDistribution
+}
+
+// InferActions infers the action every player took from one map to the next.
+// Players that are missing from either map, or moved further than one tile, are left out.
+// When several actions explain the change equally well they are considered equally likely.
+func InferActions(prev, curr models.Map) map[string]InferredAction {
+	before := New(prev, nil, "")
+	after := New(curr, nil, "")
+
+	inferred := map[string]InferredAction{}
+	for _, b := range before.Players() {
+		a, ok := after.GetPlayer(b.GetID())
+		if !ok {
+			continue
+		}
+		if i, ok := inferAction(before, b, a, curr); ok {
+			inferred[b.GetID()] = i
+		}
+	}
+	return inferred
+}
+
+func inferAction(before *MapUtility, b, a Player, curr models.Map) (InferredAction, bool) {
+	certain := func(action models.Action, outcome ActionOutcome) (InferredAction, bool) {
+		return InferredAction{
+			PlayerID:   b.GetID(),
+			Action:     action,
+			Outcome:    outcome,
+			Confidence: 1,
+			Candidates: ActionDistribution{action: 1},
+		}, true
+	}
+
+	if b.StunnedForTicks() > 0 {
+		return certain(models.Stay, Stunned)
+	}
+
+	from, to := b.GetPos(), a.GetPos()
+	if from != to {
+		action, ok := from.ActionTo(to)
+		if !ok {
+			return InferredAction{}, false
+		}
+		return certain(action, Moved)
+	}
+
+	if b.HasPowerUp() && !a.HasPowerUp() {
+		return certain(models.Explode, Exploded)
+	}
+
+	if a.StunnedForTicks() > 0 {
+		var towardsCollision []models.Action
+		for _, m := range models.Movements {
+			target, _ := from.Translate(m)
+			if !before.IsCoordinatesOutOfBounds(target) &&
+				arrays.Contains(curr.CollisionInfos, before.ConvertCoordinatesToPosition(target)) {
+				towardsCollision = append(towardsCollision, m)
+			}
+		}
+		if len(towardsCollision) > 0 {
+			return uniformInference(b.GetID(), Collided, towardsCollision), true
+		}
+		// stunned by an explosion, any action it took had no effect
+		return uniformInference(b.GetID(), Stunned, before.LegalActions(b.GetID())), true
+	}
+
+	// staying looks the same as moving into something
+	candidates := []models.Action{models.Stay}
+	for _, m := range models.Movements {
+		target, _ := from.Translate(m)
+		if _, occupied := before.PlayerAt(target); occupied || !before.IsTileAvailableForMovementTo(target) {
+			candidates = append(candidates, m)
+		}
+	}
+	return uniformInference(b.GetID(), Stayed, candidates), true
+}
+
+// uniformInference returns an inference with every candidate equally likely,
+// with the first candidate as the action
+func uniformInference(playerID string, outcome ActionOutcome, candidates []models.Action) InferredAction {
+	p := 1 / float64(len(candidates))
+	d := make(ActionDistribution, len(candidates))
+	for _, c := range candidates {
+		d[c] = p
+	}
+	return InferredAction{
+		PlayerID:   playerID,
+		Action:     candidates[0],
+		Outcome:    outcome,
+		Confidence: p,
+		Candidates: d,
+	}
+}
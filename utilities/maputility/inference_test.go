@@ -0,0 +1,73 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestInferActions(t *testing.T) {
+	// 0 1 2
+	// 3 4 5
+	// 6 7 8
+	prev := models.Map{
+		Width:  3,
+		Height: 3,
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "mover", Position: 0},
+			{ID: "bomber", Position: 2, CarryingPowerUp: true},
+			{ID: "stayer", Position: 4},
+			{ID: "crasher", Position: 6},
+			{ID: "stunned", Position: 8, StunnedForGameTicks: 2},
+		},
+	}
+	curr := models.Map{
+		Width:          3,
+		Height:         3,
+		CollisionInfos: []int{7},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "mover", Position: 1},
+			{ID: "bomber", Position: 2},
+			{ID: "stayer", Position: 4},
+			{ID: "crasher", Position: 6, StunnedForGameTicks: 10},
+			{ID: "stunned", Position: 8, StunnedForGameTicks: 1},
+		},
+	}
+
+	inferred := InferActions(prev, curr)
+	assert.Len(t, inferred, 5)
+
+	assert.Equal(t, models.Right, inferred["mover"].Action)
+	assert.Equal(t, Moved, inferred["mover"].Outcome)
+	assert.Equal(t, 1.0, inferred["mover"].Confidence)
+
+	assert.Equal(t, models.Explode, inferred["bomber"].Action)
+	assert.Equal(t, Exploded, inferred["bomber"].Outcome)
+
+	// nothing blocks the stayer, so it must have stayed
+	assert.Equal(t, models.Stay, inferred["stayer"].Action)
+	assert.Equal(t, 1.0, inferred["stayer"].Confidence)
+
+	assert.Equal(t, models.Right, inferred["crasher"].Action)
+	assert.Equal(t, Collided, inferred["crasher"].Outcome)
+
+	assert.Equal(t, Stunned, inferred["stunned"].Outcome)
+	assert.Equal(t, models.Stay, inferred["stunned"].Action)
+}
+
+func TestInferActions_ambiguousStay(t *testing.T) {
+	prev := models.Map{
+		Width:          3,
+		Height:         1,
+		CharacterInfos: []models.CharacterInfo{{ID: "a", Position: 0}},
+	}
+
+	inferred := InferActions(prev, prev)["a"]
+	assert.Equal(t, models.Stay, inferred.Action)
+	assert.Equal(t, Stayed, inferred.Outcome)
+	// staying, or moving left, up or down into the edge of the map
+	assert.Len(t, inferred.Candidates, 4)
+	assert.Equal(t, 0.25, inferred.Confidence)
+}
@@ -0,0 +1,43 @@
+package maputility
+
+import "paintbot-client/models"
+
+// LegalActions returns the actions the server accepts and carries out for the player in the
+// current state, given no action for all other players:
+//   - a stunned player can only stay
+//   - staying is always possible
+//   - moving is possible onto tiles within the map that hold neither an obstacle nor another player
+//   - exploding is possible when carrying a power-up
+//
+// Returns nil if the player is not on the map.
+func (u *MapUtility) LegalActions(playerID string) []models.Action {
+	p, ok := u.GetPlayer(playerID)
+	if !ok {
+		return nil
+	}
+	if p.StunnedForTicks() > 0 {
+		return []models.Action{models.Stay}
+	}
+
+	actions := []models.Action{models.Stay}
+	for _, a := range models.Movements {
+		target, _ := p.GetPos().Translate(a)
+		if _, occupied := u.PlayerAt(target); !occupied && u.IsTileAvailableForMovementTo(target) {
+			actions = append(actions, a)
+		}
+	}
+	if p.HasPowerUp() {
+		actions = append(actions, models.Explode)
+	}
+	return actions
+}
+
+// IsLegalAction returns true if the action is one of the LegalActions of the player
+func (u *MapUtility) IsLegalAction(playerID string, action models.Action) bool {
+	for _, a := range u.LegalActions(playerID) {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
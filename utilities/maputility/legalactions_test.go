@@ -0,0 +1,41 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_LegalActions(t *testing.T) {
+	// 0 1 2
+	// 3 # 5
+	// 6 7 8
+	mu := New(models.Map{
+		Width:               3,
+		Height:              3,
+		ObstacleUpPositions: []int{4},
+		PowerUpPositions:    []int{2},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "corner", Position: 0},
+			{ID: "edge", Position: 1, CarryingPowerUp: true},
+			{ID: "stunned", Position: 7, StunnedForGameTicks: 1, CarryingPowerUp: true},
+		},
+	}, nil, "corner")
+
+	// the edges of the map and the player to the right block all but down
+	assert.Equal(t, []models.Action{models.Stay, models.Down}, mu.LegalActions("corner"))
+
+	// the obstacle blocks down, the power-up can be walked onto
+	assert.Equal(t, []models.Action{models.Stay, models.Right, models.Explode}, mu.LegalActions("edge"))
+
+	assert.Equal(t, []models.Action{models.Stay}, mu.LegalActions("stunned"))
+	assert.Nil(t, mu.LegalActions("missing"))
+
+	assert.True(t, mu.IsLegalAction("edge", models.Explode))
+	assert.False(t, mu.IsLegalAction("corner", models.Right))
+	assert.False(t, mu.CanPlayerMoveInDirection("stunned", models.Explode))
+	assert.False(t, mu.CanIMoveInDirection(models.Up))
+	assert.True(t, mu.CanIMoveInDirection(models.Down))
+}
@@ -1,15 +1,17 @@
 package maputility
 
 import (
-	"fmt"
 	"paintbot-client/models"
-	"paintbot-client/utilities/arrays"
-
-	"github.com/RyanCarrier/dijkstra"
 )
 
+// BestPath is a shortest path between two positions, Path includes both ends
+type BestPath struct {
+	Distance int64
+	Path     []int
+}
+
 type Graph interface {
-	Shortest(from int, to int) (dijkstra.BestPath, error)
+	Shortest(from int, to int) (BestPath, error)
 }
 
 // Utility for getting information from the map object in a bit more developer friendly format
@@ -17,89 +19,94 @@ type MapUtility struct {
 	mapp            models.Map
 	graph           Graph
 	currentPlayerID string
+	grid            *tileGrid
+	players         map[string]int
+	layout          *uint64
+	settings        *models.GameSettings
+	tracker         *GameTracker
 }
 
 func (u *MapUtility) SetGraph(g Graph) {
 	u.graph = g
 }
 
+// SetTracker sets the tracker following the game, giving access to information
+// that needs more than the current map, such as invulnerability
+func (u *MapUtility) SetTracker(t *GameTracker) {
+	u.tracker = t
+}
+
+// getGraph returns the graph set with SetGraph or else a graph of the current map
+func (u *MapUtility) getGraph() Graph {
+	if u.graph == nil {
+		u.graph = GraphOfMap(*u)
+	}
+	return u.graph
+}
+
 func New(m models.Map, g Graph, currentPlayerID string) *MapUtility {
 	return &MapUtility{
 		mapp:            m,
 		currentPlayerID: currentPlayerID,
 		graph:           g,
+		grid:            newTileGrid(m),
+		players:         indexPlayers(m),
 	}
 }
 
-func GraphOfMap(u MapUtility) Graph {
-	g := dijkstra.NewGraph()
-	maxID := u.mapp.Height * u.mapp.Width
-	for i := 0; i < maxID; i++ {
-		g.AddVertex(i)
-	}
-	for i := 0; i < maxID; i++ {
-		connectedNeighbours := u.inBoundsAccessibleNeighbours(i)
-		for _, n := range connectedNeighbours {
-			err := g.AddArc(i, n, 1)
-			if err != nil {
-				panic("cannot add arc to Graph")
-			}
-		}
+// tiles returns the tile grid of the map, indexing the map if the utility
+// was not created with New
+func (u *MapUtility) tiles() *tileGrid {
+	if u.grid == nil {
+		u.grid = newTileGrid(u.mapp)
 	}
-	return g
+	return u.grid
 }
 
-// returns true if the current player can perform the given action given no action for all other players
-func (u *MapUtility) CanIMoveInDirection(action models.Action) bool {
-	info := u.getMyCharacterInfo()
-
-	if info.StunnedForGameTicks > 0 {
-		return false
-	}
-
-	if action == models.Explode {
-		return info.CarryingPowerUp
+// playerIndex returns the index in CharacterInfos of every player by ID,
+// indexing the players if the utility was not created with New
+func (u *MapUtility) playerIndex() map[string]int {
+	if u.players == nil {
+		u.players = indexPlayers(u.mapp)
 	}
+	return u.players
+}
 
-	if action == models.Stay {
-		return true
+func indexPlayers(m models.Map) map[string]int {
+	players := make(map[string]int, len(m.CharacterInfos))
+	for i := range m.CharacterInfos {
+		players[m.CharacterInfos[i].ID] = i
 	}
+	return players
+}
 
-	pos := u.GetMyCoordinates()
-	pos = u.TranslateCoordinateByAction(action, pos)
+// GraphOfMap returns a Graph of the map the utility was created with.
+// Players are not considered blocking.
+func GraphOfMap(u MapUtility) Graph {
+	return &gridGraph{grid: u.tiles()}
+}
 
-	return u.IsTileAvailableForMovementTo(pos)
+// returns true if the current player can perform the given action given no action for all other players
+func (u *MapUtility) CanIMoveInDirection(action models.Action) bool {
+	return u.CanPlayerMoveInDirection(u.currentPlayerID, action)
 }
 
 // Returns the coordinates given after an action has been performed successfully
 func (u *MapUtility) TranslateCoordinateByAction(action models.Action, pos models.Coordinates) models.Coordinates {
-	switch action {
-	case models.Left:
-		return models.Coordinates{X: pos.X - 1, Y: pos.Y}
-	case models.Right:
-		return models.Coordinates{X: pos.X + 1, Y: pos.Y}
-	case models.Up:
-		return models.Coordinates{X: pos.X, Y: pos.Y - 1}
-	case models.Down:
-		return models.Coordinates{X: pos.X, Y: pos.Y + 1}
-	case models.Stay, models.Explode:
-		return models.Coordinates{X: pos.X, Y: pos.Y}
-	default:
+	translated, ok := pos.Translate(action)
+	if !ok {
 		panic("Unknown Action: " + action)
 	}
+	return translated
 }
 
+// returns the player that has coloured the tile at the given coordinates or nil if it is not coloured
 func (u *MapUtility) GetColouredBy(coordinates models.Coordinates) *Player {
-	pos := u.ConvertCoordinatesToPosition(coordinates)
-
-	for _, c := range u.mapp.CharacterInfos {
-		if arrays.Contains(c.ColouredPosition, pos) {
-			p := u.toPlayer(c)
-			return &p
-		}
+	p, ok := u.OwnerOf(coordinates)
+	if !ok {
+		return nil
 	}
-
-	return nil
+	return &p
 }
 
 // returns list of all the coordinates containing a power up
@@ -113,8 +120,13 @@ func (u *MapUtility) GetObstacleCoordinates() []models.Coordinates {
 }
 
 // returns List of all the coordinates coloured by the given player
+// returns nil if the player is not on the map
 func (u *MapUtility) ListCoordinatesColouredByPlayer(playerId string) []models.Coordinates {
-	return u.ConvertPositionsToCoordinates(u.getCharacterInfo(playerId).ColouredPosition)
+	info, ok := u.getCharacterInfo(playerId)
+	if !ok {
+		return nil
+	}
+	return u.ConvertPositionsToCoordinates(info.ColouredPosition)
 }
 
 // returns true if tile is walkable
@@ -125,29 +137,74 @@ func (u *MapUtility) IsTileAvailableForMovementTo(coord models.Coordinates) bool
 }
 
 // returns the coordinates of the current player
+// panics if the current player is not on the map
 func (u *MapUtility) GetMyCoordinates() models.Coordinates {
-	return u.ConvertPositionToCoordinates(u.getMyCharacterInfo().Position)
-}
-
-// returns information about the current player
-func (u *MapUtility) getMyCharacterInfo() models.CharacterInfo {
-	return u.getCharacterInfo(u.currentPlayerID)
+	return u.GetMe().GetPos()
 }
 
 // returns the current player
+// panics if the current player is not on the map, use GetPlayer to check first
 func (u *MapUtility) GetMe() Player {
-	c := u.getCharacterInfo(u.currentPlayerID)
-	return Player{info: &c, utility: u}
+	p, ok := u.GetPlayer(u.currentPlayerID)
+	if !ok {
+		panic("Current player is not on the map: " + u.currentPlayerID)
+	}
+	return p
 }
 
-// returns information about the given player
-func (u *MapUtility) getCharacterInfo(playerID string) models.CharacterInfo {
+// returns the player with the given ID and false if the player is not on the map
+func (u *MapUtility) GetPlayer(playerID string) (Player, bool) {
+	i, ok := u.playerIndex()[playerID]
+	if !ok {
+		return Player{}, false
+	}
+	return u.playerAtIndex(i), true
+}
+
+// returns all players on the map, including the current player
+func (u *MapUtility) Players() []Player {
+	players := make([]Player, len(u.mapp.CharacterInfos))
+	for i := range u.mapp.CharacterInfos {
+		players[i] = u.playerAtIndex(i)
+	}
+	return players
+}
+
+// returns all players on the map except the current player
+func (u *MapUtility) Opponents() []Player {
+	return u.OpponentsOf(u.currentPlayerID)
+}
+
+// returns all players on the map except the given player
+func (u *MapUtility) OpponentsOf(playerID string) []Player {
+	var opponents []Player
 	for i := range u.mapp.CharacterInfos {
-		if u.mapp.CharacterInfos[i].ID == playerID {
-			return u.mapp.CharacterInfos[i]
+		if u.mapp.CharacterInfos[i].ID != playerID {
+			opponents = append(opponents, u.playerAtIndex(i))
 		}
 	}
-	panic("Trying to find invalid playerID: " + playerID)
+	return opponents
+}
+
+// returns the player standing at the given coordinates and false if the tile is empty
+func (u *MapUtility) PlayerAt(coord models.Coordinates) (Player, bool) {
+	if u.IsCoordinatesOutOfBounds(coord) {
+		return Player{}, false
+	}
+	i := u.tiles().occupant(u.ConvertCoordinatesToPosition(coord))
+	if i == noPlayer {
+		return Player{}, false
+	}
+	return u.playerAtIndex(i), true
+}
+
+// returns information about the given player
+func (u *MapUtility) getCharacterInfo(playerID string) (models.CharacterInfo, bool) {
+	i, ok := u.playerIndex()[playerID]
+	if !ok {
+		return models.CharacterInfo{}, false
+	}
+	return u.mapp.CharacterInfos[i], true
 }
 
 // Returns true if the coordinate is withing the game field
@@ -168,19 +225,7 @@ func (u *MapUtility) GetTileAt(coordinates models.Coordinates) models.Tile {
 }
 
 func (u *MapUtility) getTileAtPosition(position int) models.Tile {
-	if arrays.Contains(u.mapp.ObstacleUpPositions, position) {
-		return models.Obstacle
-	}
-
-	if arrays.Contains(u.mapp.PowerUpPositions, position) {
-		return models.PowerUp
-	}
-
-	if arrays.Contains(u.getPlayerPositions(), position) {
-		return models.Player
-	}
-
-	return models.Open
+	return u.tiles().tile(position)
 }
 
 // Converts a position in the flattened single array representation
@@ -220,44 +265,17 @@ func (u *MapUtility) ConvertCoordinatesToPositions(coordinates []models.Coordina
 
 // DistanceTo returns the distance to a specified coordinate from the players current position
 func (u *MapUtility) DistanceTo(destination models.Coordinates) (int, error) {
-	if !u.IsTileAvailableForMovementTo(destination) || u.IsCoordinatesOutOfBounds(destination) {
-		return 0, fmt.Errorf("coordinates are unreachable: %v", destination)
-	}
-
-	myPos := u.GetMe().info.Position
-
-	destinationPos := u.ConvertCoordinatesToPosition(destination)
-	bestPath, err := u.graph.Shortest(myPos, destinationPos)
-	if err != nil {
-		return 0, err
-	}
-
-	// Assuming Width * Height < int.max
-	return int(bestPath.Distance), nil
+	return u.DistanceFrom(u.currentPlayerID, destination)
 }
 
+// DirectionToPoint returns the action moving the current player to the neighbouring position p.
+// panics if p is not a neighbour, see Path.NextAction for a safe alternative
 func (u *MapUtility) DirectionToPoint(p int) models.Action {
 	coord := u.ConvertPositionToCoordinates(p)
 	myCoord := u.GetMyCoordinates()
 
-	if myCoord.X+1 == coord.X &&
-		myCoord.Y == coord.Y {
-		return models.Right
-	}
-
-	if myCoord.X-1 == coord.X &&
-		myCoord.Y == coord.Y {
-		return models.Left
-	}
-
-	if myCoord.X == coord.X &&
-		myCoord.Y-1 == coord.Y {
-		return models.Up
-	}
-
-	if myCoord.X == coord.X &&
-		myCoord.Y+1 == coord.Y {
-		return models.Down
+	if action, ok := myCoord.ActionTo(coord); ok && action != models.Stay {
+		return action
 	}
 
 	panic("p should be a neighbour")
@@ -265,61 +283,19 @@ func (u *MapUtility) DirectionToPoint(p int) models.Action {
 
 // ShortestPathTo returns the shortest path to the given destination.
 // If the destination is unreachable an error is returned.
-func (u *MapUtility) ShortestPathTo(destination models.Coordinates) ([]int, error) {
-	if !u.IsTileAvailableForMovementTo(destination) || u.IsCoordinatesOutOfBounds(destination) {
-		return nil, fmt.Errorf("coordinates are unreachable: %v", destination)
-	}
-
-	myPos := u.GetMe().info.Position
-	destinationPos := u.ConvertCoordinatesToPosition(destination)
-	g := u.graph
-
-	bestPath, err := g.Shortest(myPos, destinationPos)
-	if err != nil {
-		return nil, err
-	}
-	return bestPath.Path, nil
+func (u *MapUtility) ShortestPathTo(destination models.Coordinates) (Path, error) {
+	return u.ShortestPathFrom(u.currentPlayerID, destination)
 }
 
+// IsAnyPlayerWithinExplosionRange returns true if an explosion at the current position
+// of the current player would reach any opponent, see ExplosionAt
 func (u *MapUtility) IsAnyPlayerWithinExplosionRange() bool {
-	pps := u.getPlayerPositions()
-	pcs := u.ConvertPositionsToCoordinates(pps)
-	myCoord := u.GetMyCoordinates()
-	for _, pc := range pcs {
-		if pc == myCoord {
-			continue
-		}
-		if d, _ := u.DistanceTo(pc); d <= 4 {
-			return true
-		}
-	}
-	return false
-}
-
-func (u *MapUtility) inBoundsAccessibleNeighbours(pos int) []int {
-	coord := u.ConvertPositionToCoordinates(pos)
-
-	var neighbours []int
-	for _, a := range models.Movements {
-		neighbour := u.TranslateCoordinateByAction(a, coord)
-		if !u.IsCoordinatesOutOfBounds(neighbour) && u.IsTileAvailableForMovementTo(neighbour) {
-			neighbours = append(neighbours, u.ConvertCoordinatesToPosition(neighbour))
-		}
-	}
-	return neighbours
-}
-
-func (u *MapUtility) getPlayerPositions() []int {
-	positions := make([]int, len(u.mapp.CharacterInfos))
-	for i := range u.mapp.CharacterInfos {
-		positions[i] = u.mapp.CharacterInfos[i].Position
-	}
-	return positions
+	return u.IsAnyOpponentWithinExplosionRangeOf(u.currentPlayerID)
 }
 
-func (u *MapUtility) toPlayer(info models.CharacterInfo) Player {
+func (u *MapUtility) playerAtIndex(i int) Player {
 	return Player{
-		info:    &info,
+		info:    &u.mapp.CharacterInfos[i],
 		utility: u,
 	}
 }
@@ -0,0 +1,54 @@
+package maputility
+
+import "paintbot-client/models"
+
+// TileDistance is a tile and the number of moves needed to reach it
+type TileDistance struct {
+	Coordinates models.Coordinates
+	Distance    int
+}
+
+// NearestWhere returns the closest walkable tile to from, from itself included, for which the
+// predicate returns true. Players are not considered blocking.
+// Returns false if no such tile can be reached.
+func (u *MapUtility) NearestWhere(from models.Coordinates, predicate func(c models.Coordinates) bool) (TileDistance, bool) {
+	nearest := u.KNearestWhere(from, 1, predicate)
+	if len(nearest) == 0 {
+		return TileDistance{}, false
+	}
+	return nearest[0], true
+}
+
+// KNearestWhere returns the k closest walkable tiles to from, from itself included, for which the
+// predicate returns true, closest first. Fewer tiles are returned if fewer can be reached.
+// The map is only searched until k tiles are found. Players are not considered blocking.
+func (u *MapUtility) KNearestWhere(from models.Coordinates, k int, predicate func(c models.Coordinates) bool) []TileDistance {
+	if k <= 0 {
+		return nil
+	}
+
+	var found []TileDistance
+	bfsUntil(u.tiles(), u.sourcePosition(from), nil, func(pos, dist int) bool {
+		c := u.ConvertPositionToCoordinates(pos)
+		if predicate(c) {
+			found = append(found, TileDistance{Coordinates: c, Distance: dist})
+		}
+		return len(found) == k
+	})
+	return found
+}
+
+// IsPowerUp returns true if there is a power-up at the coordinates
+func (u *MapUtility) IsPowerUp(coord models.Coordinates) bool {
+	return u.GetTileAt(coord) == models.PowerUp
+}
+
+// IsAdjacentToOpponent returns true if an opponent of the current player stands next to the coordinates
+func (u *MapUtility) IsAdjacentToOpponent(coord models.Coordinates) bool {
+	for _, n := range coord.Neighbours() {
+		if p, ok := u.PlayerAt(n); ok && p.GetID() != u.currentPlayerID {
+			return true
+		}
+	}
+	return false
+}
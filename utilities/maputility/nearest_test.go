@@ -0,0 +1,48 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_NearestWhere(t *testing.T) {
+	// 0 1 # 3 4
+	// 5 6 7 8 9
+	mu := New(models.Map{
+		Width:               5,
+		Height:              2,
+		ObstacleUpPositions: []int{2},
+		PowerUpPositions:    []int{3, 9},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0, ColouredPosition: []int{0, 1, 5}},
+			{ID: "other", Position: 4},
+		},
+	}, nil, "myId")
+	me := mu.GetMyCoordinates()
+
+	nearest, ok := mu.NearestWhere(me, mu.IsPowerUp)
+	assert.True(t, ok)
+	assert.Equal(t, 5, nearest.Distance)
+	assert.True(t, mu.IsPowerUp(nearest.Coordinates))
+
+	nearest, ok = mu.NearestWhere(me, mu.IsUnpainted)
+	assert.True(t, ok)
+	assert.Equal(t, 2, nearest.Distance)
+
+	assert.ElementsMatch(t, []TileDistance{
+		{Coordinates: models.Coordinates{X: 3, Y: 0}, Distance: 5},
+		{Coordinates: models.Coordinates{X: 4, Y: 1}, Distance: 5},
+	}, mu.KNearestWhere(me, 3, mu.IsPowerUp))
+
+	nearest, ok = mu.NearestWhere(me, mu.IsAdjacentToOpponent)
+	assert.True(t, ok)
+	assert.Equal(t, 5, nearest.Distance)
+	assert.False(t, mu.IsAdjacentToOpponent(models.Coordinates{X: 3, Y: 1}))
+
+	_, ok = mu.NearestWhere(me, func(c models.Coordinates) bool { return false })
+	assert.False(t, ok)
+	assert.Nil(t, mu.KNearestWhere(me, 0, mu.IsPowerUp))
+}
@@ -0,0 +1,189 @@
+package maputility
+
+import (
+	"paintbot-client/models"
+)
+
+// behaviourSmoothing is the share of every behaviour's prediction spread over all possible actions,
+// so no behaviour is ruled out by a single surprising action
+const behaviourSmoothing = 0.05
+
+// Behaviour is a simple strategy a player may follow, with the weight it is given before any
+// action of the player has been observed
+type Behaviour struct {
+	Name  string
+	Model ActionModel
+	Prior float64
+}
+
+// DefaultBehaviours returns the behaviours a new OpponentModel starts from when none are given
+func DefaultBehaviours() []Behaviour {
+	return []Behaviour{
+		{Name: "power-up seeker", Model: PowerUpSeekerModel, Prior: 2},
+		{Name: "painter", Model: PainterModel, Prior: 2},
+		{Name: "random", Model: UniformActionModel, Prior: 1},
+	}
+}
+
+// PowerUpSeekerModel assumes a player without a power-up heads for the nearest one,
+// and otherwise acts at random
+func PowerUpSeekerModel(u *MapUtility, p Player) ActionDistribution {
+	if p.HasPowerUp() {
+		return UniformActionModel(u, p)
+	}
+	g := u.tiles()
+	return u.stepTowardsNearest(p, func(pos int) bool {
+		return g.tile(pos) == models.PowerUp
+	})
+}
+
+// PainterModel assumes a player heads for the nearest tile it has not coloured
+func PainterModel(u *MapUtility, p Player) ActionDistribution {
+	g := u.tiles()
+	me := u.playerIndex()[p.GetID()]
+	return u.stepTowardsNearest(p, func(pos int) bool {
+		return g.owner(pos) != me
+	})
+}
+
+// stepTowardsNearest predicts the player takes the first step of a shortest path to the nearest
+// position matching the target, without passing other players.
+// Falls back to UniformActionModel if the player is stunned or no target can be reached.
+func (u *MapUtility) stepTowardsNearest(p Player, target func(pos int) bool) ActionDistribution {
+	if p.StunnedForTicks() > 0 {
+		return UniformActionModel(u, p)
+	}
+
+	others := u.OpponentCoordinatesOf(p.GetID())
+	field := u.DistanceFieldAvoiding(p.GetPos(), others)
+
+	nearest, best := Unreachable, 0
+	for _, pos := range field.Reachable() {
+		d := field.Distance(pos)
+		if d > 0 && target(pos) && (nearest == Unreachable || d < best) {
+			nearest, best = pos, d
+		}
+	}
+	if nearest == Unreachable {
+		return UniformActionModel(u, p)
+	}
+
+	action, ok := u.ConvertPositionsToPath(field.Path(nearest)).NextAction()
+	if !ok {
+		return UniformActionModel(u, p)
+	}
+	return ActionDistribution{action: 1}
+}
+
+// OpponentModel learns during a game which behaviours every player follows, from their
+// observed actions, and predicts their next action as a mix of those behaviours
+type OpponentModel struct {
+	behaviours []Behaviour
+	// weights holds the weight of every behaviour, in the order of behaviours, by player ID
+	weights map[string][]float64
+}
+
+// NewOpponentModel returns a model mixing the given behaviours, DefaultBehaviours if none are given
+func NewOpponentModel(behaviours ...Behaviour) *OpponentModel {
+	if len(behaviours) == 0 {
+		behaviours = DefaultBehaviours()
+	}
+	return &OpponentModel{
+		behaviours: behaviours,
+		weights:    map[string][]float64{},
+	}
+}
+
+// Observe updates the weights of the behaviours of every player from the actions inferred
+// between prev and the map that followed it, see InferActions and TickDelta.Actions.
+// Every behaviour gains weight in proportion to how well it predicted the action.
+func (m *OpponentModel) Observe(prev models.Map, actions map[string]InferredAction) {
+	u := New(prev, nil, "")
+	for id, inferred := range actions {
+		p, ok := u.GetPlayer(id)
+		if !ok || p.StunnedForTicks() > 0 || inferred.Outcome == Stunned {
+			// the action had no effect, it says nothing about the behaviour
+			continue
+		}
+
+		likelihoods := make([]float64, len(m.behaviours))
+		total := 0.0
+		for i, b := range m.behaviours {
+			predicted := m.smoothed(u, p, b.Model)
+			for a, candidate := range inferred.Candidates {
+				likelihoods[i] += candidate * predicted[a]
+			}
+			total += likelihoods[i]
+		}
+		if total == 0 {
+			continue
+		}
+
+		weights := m.weightsOf(id)
+		for i := range weights {
+			weights[i] += likelihoods[i] / total
+		}
+	}
+}
+
+// Predict returns the probability of every next action of the player.
+// It can be used as the Model of a CollisionPredictor.
+func (m *OpponentModel) Predict(u *MapUtility, p Player) ActionDistribution {
+	weights := m.weightsOf(p.GetID())
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	d := ActionDistribution{}
+	for i, b := range m.behaviours {
+		for a, prob := range m.smoothed(u, p, b.Model) {
+			d[a] += weights[i] / total * prob
+		}
+	}
+	return d
+}
+
+// Weights returns the share of every behaviour, by behaviour name, in the predictions for the player
+func (m *OpponentModel) Weights(playerID string) map[string]float64 {
+	weights := m.weightsOf(playerID)
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	shares := make(map[string]float64, len(weights))
+	for i, b := range m.behaviours {
+		shares[b.Name] = weights[i] / total
+	}
+	return shares
+}
+
+// Reset forgets everything learned about all players
+func (m *OpponentModel) Reset() {
+	m.weights = map[string][]float64{}
+}
+
+func (m *OpponentModel) weightsOf(playerID string) []float64 {
+	weights, ok := m.weights[playerID]
+	if !ok {
+		weights = make([]float64, len(m.behaviours))
+		for i, b := range m.behaviours {
+			weights[i] = b.Prior
+		}
+		m.weights[playerID] = weights
+	}
+	return weights
+}
+
+// smoothed returns the prediction of the model mixed with a small share of UniformActionModel
+func (m *OpponentModel) smoothed(u *MapUtility, p Player, model ActionModel) ActionDistribution {
+	d := ActionDistribution{}
+	for a, prob := range model(u, p) {
+		d[a] += (1 - behaviourSmoothing) * prob
+	}
+	for a, prob := range UniformActionModel(u, p) {
+		d[a] += behaviourSmoothing * prob
+	}
+	return d
+}
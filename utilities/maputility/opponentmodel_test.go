@@ -0,0 +1,57 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestPowerUpSeekerModel(t *testing.T) {
+	mu := New(models.Map{
+		Width:            5,
+		Height:           1,
+		PowerUpPositions: []int{0},
+		CharacterInfos:   []models.CharacterInfo{{ID: "a", Position: 3}},
+	}, nil, "")
+	a, _ := mu.GetPlayer("a")
+
+	assert.Equal(t, ActionDistribution{models.Left: 1}, PowerUpSeekerModel(mu, a))
+}
+
+func TestOpponentModel_learnsBehaviour(t *testing.T) {
+	// the power-up is to the left, unpainted tiles to the right
+	prev := models.Map{
+		Width:            5,
+		Height:           1,
+		PowerUpPositions: []int{0},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "a", Position: 2, ColouredPosition: []int{0, 1, 2}},
+		},
+	}
+	curr := prev
+	curr.CharacterInfos = []models.CharacterInfo{
+		{ID: "a", Position: 1, ColouredPosition: []int{0, 1, 2}},
+	}
+
+	model := NewOpponentModel()
+	before := model.Weights("a")
+	for i := 0; i < 5; i++ {
+		model.Observe(prev, InferActions(prev, curr))
+	}
+	after := model.Weights("a")
+	assert.Greater(t, after["power-up seeker"], before["power-up seeker"])
+	assert.Less(t, after["painter"], before["painter"])
+
+	mu := New(prev, nil, "")
+	a, _ := mu.GetPlayer("a")
+	predicted := model.Predict(mu, a)
+	assert.Greater(t, predicted[models.Left], predicted[models.Right])
+
+	total := 0.0
+	for _, p := range predicted {
+		total += p
+	}
+	assert.InDelta(t, 1, total, 1e-9)
+}
@@ -0,0 +1,92 @@
+package maputility
+
+import "paintbot-client/models"
+
+// OwnerOf returns the player that has coloured the tile and false if it is not coloured
+func (u *MapUtility) OwnerOf(coord models.Coordinates) (Player, bool) {
+	if u.IsCoordinatesOutOfBounds(coord) {
+		return Player{}, false
+	}
+	owner := u.tiles().owner(u.ConvertCoordinatesToPosition(coord))
+	if owner == noPlayer {
+		return Player{}, false
+	}
+	return u.playerAtIndex(owner), true
+}
+
+// IsUnpainted returns true if the tile is walkable and not coloured by any player
+func (u *MapUtility) IsUnpainted(coord models.Coordinates) bool {
+	if u.IsCoordinatesOutOfBounds(coord) {
+		return false
+	}
+	pos := u.ConvertCoordinatesToPosition(coord)
+	g := u.tiles()
+	return g.walkable(pos) && g.owner(pos) == noPlayer
+}
+
+// TileCounts returns the number of tiles coloured by every player, by player ID
+func (u *MapUtility) TileCounts() map[string]int {
+	g := u.tiles()
+	counts := make(map[string]int, len(g.owned))
+	for i, owned := range g.owned {
+		counts[u.mapp.CharacterInfos[i].ID] = owned
+	}
+	return counts
+}
+
+// UnpaintedCount returns the number of walkable tiles not coloured by any player
+func (u *MapUtility) UnpaintedCount() int {
+	return u.tiles().unpainted
+}
+
+// UnpaintedCoordinates returns all walkable tiles not coloured by any player
+func (u *MapUtility) UnpaintedCoordinates() []models.Coordinates {
+	g := u.tiles()
+	coords := make([]models.Coordinates, 0, g.unpainted)
+	for pos := range g.tiles {
+		if g.walkable(pos) && g.owner(pos) == noPlayer {
+			coords = append(coords, u.ConvertPositionToCoordinates(pos))
+		}
+	}
+	return coords
+}
+
+// Leader returns the player with the most points, on equal points the one with the most
+// coloured tiles. Returns false if there are no players.
+func (u *MapUtility) Leader() (Player, bool) {
+	g := u.tiles()
+	leader := noPlayer
+	for i, c := range u.mapp.CharacterInfos {
+		if leader == noPlayer {
+			leader = i
+			continue
+		}
+		best := u.mapp.CharacterInfos[leader]
+		if c.Points > best.Points || (c.Points == best.Points && g.owned[i] > g.owned[leader]) {
+			leader = i
+		}
+	}
+	if leader == noPlayer {
+		return Player{}, false
+	}
+	return u.playerAtIndex(leader), true
+}
+
+// IsOwnedByLeader returns true if the tile is coloured by the Leader
+func (u *MapUtility) IsOwnedByLeader(coord models.Coordinates) bool {
+	leader, ok := u.Leader()
+	if !ok {
+		return false
+	}
+	owner, ok := u.OwnerOf(coord)
+	return ok && owner.GetID() == leader.GetID()
+}
+
+// CoordinatesOwnedByLeader returns the tiles coloured by the Leader
+func (u *MapUtility) CoordinatesOwnedByLeader() []models.Coordinates {
+	leader, ok := u.Leader()
+	if !ok {
+		return nil
+	}
+	return leader.GetColouredPositions()
+}
@@ -0,0 +1,50 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_Ownership(t *testing.T) {
+	// 0 1 2
+	// 3 4 #
+	mu := New(models.Map{
+		Width:               3,
+		Height:              2,
+		ObstacleUpPositions: []int{5},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "a", Position: 0, ColouredPosition: []int{0, 1}, Points: 2},
+			{ID: "b", Position: 4, ColouredPosition: []int{4}, Points: 2},
+		},
+	}, nil, "a")
+
+	owner, ok := mu.OwnerOf(models.Coordinates{X: 1, Y: 0})
+	assert.True(t, ok)
+	assert.Equal(t, "a", owner.GetID())
+	_, ok = mu.OwnerOf(models.Coordinates{X: 2, Y: 0})
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, mu.TileCounts())
+	assert.Equal(t, 2, mu.UnpaintedCount())
+	assert.Equal(t, []models.Coordinates{{X: 2, Y: 0}, {X: 0, Y: 1}}, mu.UnpaintedCoordinates())
+	assert.True(t, mu.IsUnpainted(models.Coordinates{X: 2, Y: 0}))
+	assert.False(t, mu.IsUnpainted(models.Coordinates{X: 2, Y: 1}))
+
+	leader, ok := mu.Leader()
+	assert.True(t, ok)
+	assert.Equal(t, "a", leader.GetID())
+	assert.True(t, mu.IsOwnedByLeader(models.Coordinates{X: 0, Y: 0}))
+	assert.False(t, mu.IsOwnedByLeader(models.Coordinates{X: 1, Y: 1}))
+	assert.Len(t, mu.CoordinatesOwnedByLeader(), 2)
+}
+
+func BenchmarkMapUtility_TileCounts(b *testing.B) {
+	m := fullSizeMap()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		New(m, nil, "a").TileCounts()
+	}
+}
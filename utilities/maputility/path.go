@@ -0,0 +1,145 @@
+package maputility
+
+import "paintbot-client/models"
+
+// Path is a sequence of neighbouring tiles on the map, starting at the tile
+// it was searched from. A path with only the start tile has length 0.
+type Path struct {
+	positions []int
+	utility   *MapUtility
+}
+
+// ConvertPositionsToPath returns the path through the given positions, starting with the first
+func (u *MapUtility) ConvertPositionsToPath(positions []int) Path {
+	return Path{positions: positions, utility: u}
+}
+
+// Len returns the number of moves needed to follow the path
+func (p Path) Len() int {
+	if len(p.positions) == 0 {
+		return 0
+	}
+	return len(p.positions) - 1
+}
+
+// IsEmpty returns true if the path does not even contain a start tile
+func (p Path) IsEmpty() bool {
+	return len(p.positions) == 0
+}
+
+// Positions returns the positions of the path, starting with the start tile
+func (p Path) Positions() []int {
+	return append([]int(nil), p.positions...)
+}
+
+// Coordinates returns the coordinates of the path, starting with the start tile
+func (p Path) Coordinates() []models.Coordinates {
+	if p.IsEmpty() {
+		return nil
+	}
+	return p.utility.ConvertPositionsToCoordinates(p.positions)
+}
+
+// Start returns the first tile of the path
+func (p Path) Start() (models.Coordinates, bool) {
+	if p.IsEmpty() {
+		return models.Coordinates{}, false
+	}
+	return p.utility.ConvertPositionToCoordinates(p.positions[0]), true
+}
+
+// End returns the last tile of the path
+func (p Path) End() (models.Coordinates, bool) {
+	if p.IsEmpty() {
+		return models.Coordinates{}, false
+	}
+	return p.utility.ConvertPositionToCoordinates(p.positions[len(p.positions)-1]), true
+}
+
+// NextAction returns the action moving from the start tile to the next tile of the path.
+// Returns false if there is no next tile, when already standing on the destination.
+func (p Path) NextAction() (models.Action, bool) {
+	if len(p.positions) < 2 {
+		return models.Stay, false
+	}
+	from := p.utility.ConvertPositionToCoordinates(p.positions[0])
+	to := p.utility.ConvertPositionToCoordinates(p.positions[1])
+	action, ok := from.ActionTo(to)
+	if !ok || action == models.Stay {
+		return models.Stay, false
+	}
+	return action, true
+}
+
+// Tiles returns the type of object at every tile of the path, starting with the start tile
+func (p Path) Tiles() []models.Tile {
+	if p.IsEmpty() {
+		return nil
+	}
+	tiles := make([]models.Tile, len(p.positions))
+	for i, pos := range p.positions {
+		tiles[i] = p.utility.getTileAtPosition(pos)
+	}
+	return tiles
+}
+
+// Prefix returns the first moves of the path. The whole path is returned
+// if it is not longer than moves.
+func (p Path) Prefix(moves int) Path {
+	if moves < 0 {
+		moves = 0
+	}
+	if moves >= p.Len() {
+		return p
+	}
+	return Path{positions: p.positions[:moves+1], utility: p.utility}
+}
+
+// Truncate returns the path up to, but not including, the first tile after
+// the start tile for which stop returns true
+func (p Path) Truncate(stop func(c models.Coordinates) bool) Path {
+	for i := 1; i < len(p.positions); i++ {
+		if stop(p.utility.ConvertPositionToCoordinates(p.positions[i])) {
+			return p.Prefix(i - 1)
+		}
+	}
+	return p
+}
+
+// UnpaintedCount returns the number of tiles entered along the path that are not coloured by any player
+func (p Path) UnpaintedCount() int {
+	if len(p.positions) < 2 {
+		return 0
+	}
+	count := 0
+	g := p.utility.tiles()
+	for _, pos := range p.entered() {
+		if g.owner(pos) == noPlayer {
+			count++
+		}
+	}
+	return count
+}
+
+// OwnedCounts returns, by player ID, the number of tiles entered along the path that the player has coloured
+func (p Path) OwnedCounts() map[string]int {
+	counts := map[string]int{}
+	if len(p.positions) < 2 {
+		return counts
+	}
+	g := p.utility.tiles()
+	for _, pos := range p.entered() {
+		if owner := g.owner(pos); owner != noPlayer {
+			counts[p.utility.mapp.CharacterInfos[owner].ID]++
+		}
+	}
+	return counts
+}
+
+// entered returns the positions of the path after the start tile
+func (p Path) entered() []int {
+	if len(p.positions) < 2 {
+		return nil
+	}
+	return p.positions[1:]
+}
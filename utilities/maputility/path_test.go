@@ -0,0 +1,63 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestPath(t *testing.T) {
+	// 0 1 2
+	// 3 4 5
+	mu := New(models.Map{
+		Width:            3,
+		Height:           2,
+		PowerUpPositions: []int{5},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0, ColouredPosition: []int{0, 1}},
+			{ID: "other", Position: 3, ColouredPosition: []int{3, 2}},
+		},
+	}, nil, "myId")
+
+	path := mu.ConvertPositionsToPath([]int{0, 1, 2, 5})
+	assert.Equal(t, 3, path.Len())
+	action, ok := path.NextAction()
+	assert.True(t, ok)
+	assert.Equal(t, models.Right, action)
+	assert.Equal(t, []models.Tile{models.Player, models.Open, models.Open, models.PowerUp}, path.Tiles())
+	assert.Equal(t, 1, path.UnpaintedCount())
+	assert.Equal(t, map[string]int{"myId": 1, "other": 1}, path.OwnedCounts())
+
+	end, ok := path.End()
+	assert.True(t, ok)
+	assert.Equal(t, models.Coordinates{X: 2, Y: 1}, end)
+
+	assert.Equal(t, []int{0, 1}, path.Prefix(1).Positions())
+	assert.Equal(t, path, path.Prefix(10))
+
+	truncated := path.Truncate(func(c models.Coordinates) bool {
+		return mu.GetTileAt(c) == models.PowerUp
+	})
+	assert.Equal(t, []int{0, 1, 2}, truncated.Positions())
+}
+
+func TestPath_NextActionOnDestination(t *testing.T) {
+	mu := New(models.Map{
+		Width:          3,
+		Height:         3,
+		CharacterInfos: []models.CharacterInfo{{ID: "myId", Position: 4}},
+	}, nil, "myId")
+
+	path, err := mu.ShortestPathTo(models.Coordinates{X: 1, Y: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, path.Len())
+	_, ok := path.NextAction()
+	assert.False(t, ok)
+
+	_, ok = Path{}.NextAction()
+	assert.False(t, ok)
+	assert.Equal(t, 0, Path{}.UnpaintedCount())
+	assert.Empty(t, Path{}.OwnedCounts())
+}
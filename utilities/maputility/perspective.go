@@ -0,0 +1,117 @@
+package maputility
+
+import (
+	"fmt"
+
+	"paintbot-client/models"
+)
+
+// ForPlayer returns a utility answering every question about the current player, such as
+// DistanceTo, CanIMoveInDirection or Opponents, for the given player instead.
+// It shares the indexes, graph, settings and tracker of u.
+func (u *MapUtility) ForPlayer(playerID string) *MapUtility {
+	// build the lazy indexes first so they are shared rather than built again
+	u.tiles()
+	u.playerIndex()
+	u.getGraph()
+	u.LayoutHash()
+
+	view := *u
+	view.currentPlayerID = playerID
+	return &view
+}
+
+// returns the coordinates of the given player and false if the player is not on the map
+func (u *MapUtility) GetCoordinatesOf(playerID string) (models.Coordinates, bool) {
+	p, ok := u.GetPlayer(playerID)
+	if !ok {
+		return models.Coordinates{}, false
+	}
+	return p.GetPos(), true
+}
+
+// returns true if the given player can perform the given action given no action for all other players,
+// see LegalActions
+func (u *MapUtility) CanPlayerMoveInDirection(playerID string, action models.Action) bool {
+	return u.IsLegalAction(playerID, action)
+}
+
+// DistanceFrom returns the distance to a specified coordinate from the given players current position
+func (u *MapUtility) DistanceFrom(playerID string, destination models.Coordinates) (int, error) {
+	path, err := u.ShortestPathFrom(playerID, destination)
+	if err != nil {
+		return 0, err
+	}
+	return path.Len(), nil
+}
+
+// ShortestPathFrom returns the shortest path from the given player to the given destination.
+// If the player is not on the map or the destination is unreachable an error is returned.
+func (u *MapUtility) ShortestPathFrom(playerID string, destination models.Coordinates) (Path, error) {
+	if !u.IsTileAvailableForMovementTo(destination) || u.IsCoordinatesOutOfBounds(destination) {
+		return Path{}, fmt.Errorf("coordinates are unreachable: %v", destination)
+	}
+
+	p, ok := u.GetPlayer(playerID)
+	if !ok {
+		return Path{}, fmt.Errorf("player is not on the map: %s", playerID)
+	}
+
+	destinationPos := u.ConvertCoordinatesToPosition(destination)
+	bestPath, err := u.getGraph().Shortest(p.info.Position, destinationPos)
+	if err != nil {
+		return Path{}, err
+	}
+	return u.ConvertPositionsToPath(bestPath.Path), nil
+}
+
+// ShortestPathAvoidingFrom returns the shortest path from the given player to the destination
+// when the given coordinates are treated as obstacles.
+// If the player is not on the map or the destination is unreachable an error is returned.
+func (u *MapUtility) ShortestPathAvoidingFrom(playerID string, destination models.Coordinates, blockers []models.Coordinates) (Path, error) {
+	if !u.IsTileAvailableForMovementTo(destination) || u.IsCoordinatesOutOfBounds(destination) {
+		return Path{}, fmt.Errorf("coordinates are unreachable: %v", destination)
+	}
+
+	p, ok := u.GetPlayer(playerID)
+	if !ok {
+		return Path{}, fmt.Errorf("player is not on the map: %s", playerID)
+	}
+
+	path, ok := u.DistanceFieldAvoiding(p.GetPos(), blockers).PathTo(destination)
+	if !ok {
+		return Path{}, fmt.Errorf("coordinates are unreachable: %v", destination)
+	}
+	return u.ConvertPositionsToPath(path), nil
+}
+
+// DistanceToAvoidingFrom returns the distance from the given player to the destination
+// when the given coordinates are treated as obstacles
+func (u *MapUtility) DistanceToAvoidingFrom(playerID string, destination models.Coordinates, blockers []models.Coordinates) (int, error) {
+	path, err := u.ShortestPathAvoidingFrom(playerID, destination, blockers)
+	if err != nil {
+		return 0, err
+	}
+	return path.Len(), nil
+}
+
+// IsAnyOpponentWithinExplosionRangeOf returns true if an explosion at the current position
+// of the given player would reach any of its opponents, see ExplosionAt
+func (u *MapUtility) IsAnyOpponentWithinExplosionRangeOf(playerID string) bool {
+	p, ok := u.GetPlayer(playerID)
+	if !ok {
+		return false
+	}
+	e, _ := u.ExplosionAt(playerID, p.GetPos())
+	return len(e.Stunned)+len(e.Invulnerable) > 0
+}
+
+// OpponentCoordinatesOf returns the current coordinates of all opponents of the given player,
+// for use as blockers
+func (u *MapUtility) OpponentCoordinatesOf(playerID string) []models.Coordinates {
+	var coords []models.Coordinates
+	for _, o := range u.OpponentsOf(playerID) {
+		coords = append(coords, o.GetPos())
+	}
+	return coords
+}
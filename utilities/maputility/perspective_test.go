@@ -0,0 +1,69 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_ForPlayer(t *testing.T) {
+	// 0 1 2
+	// 3 4 5
+	mu := New(models.Map{
+		Width:               3,
+		Height:              2,
+		ObstacleUpPositions: []int{1},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0},
+			{ID: "other", Position: 2, CarryingPowerUp: true},
+		},
+	}, nil, "myId")
+
+	other := mu.ForPlayer("other")
+	assert.Equal(t, models.Coordinates{X: 2, Y: 0}, other.GetMyCoordinates())
+	assert.True(t, other.CanIMoveInDirection(models.Explode))
+	assert.False(t, mu.CanIMoveInDirection(models.Explode))
+	if opponents := other.Opponents(); assert.Len(t, opponents, 1) {
+		assert.Equal(t, "myId", opponents[0].GetID())
+	}
+
+	d, err := other.DistanceTo(models.Coordinates{X: 0, Y: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, d)
+}
+
+func TestMapUtility_queriesForAnyPlayer(t *testing.T) {
+	mu := New(models.Map{
+		Width:  3,
+		Height: 2,
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0},
+			{ID: "other", Position: 5},
+		},
+	}, nil, "myId")
+
+	c, ok := mu.GetCoordinatesOf("other")
+	assert.True(t, ok)
+	assert.Equal(t, models.Coordinates{X: 2, Y: 1}, c)
+
+	d, err := mu.DistanceFrom("other", models.Coordinates{X: 2, Y: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, d)
+
+	path, err := mu.ShortestPathFrom("other", models.Coordinates{X: 0, Y: 1})
+	assert.NoError(t, err)
+	action, _ := path.NextAction()
+	assert.Equal(t, models.Left, action)
+
+	_, err = mu.ShortestPathAvoidingFrom("other", models.Coordinates{X: 0, Y: 1}, []models.Coordinates{{X: 1, Y: 1}, {X: 1, Y: 0}})
+	assert.Error(t, err)
+
+	assert.False(t, mu.CanPlayerMoveInDirection("other", models.Down))
+	assert.True(t, mu.CanPlayerMoveInDirection("other", models.Up))
+	assert.True(t, mu.IsAnyOpponentWithinExplosionRangeOf("other"))
+
+	_, err = mu.DistanceFrom("missing", models.Coordinates{})
+	assert.Error(t, err)
+}
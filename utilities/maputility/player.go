@@ -19,6 +19,21 @@ func (p Player) StunnedForTicks() int {
 	return p.info.StunnedForGameTicks
 }
 
+// returns true if the player cannot be stunned because its stun recently ended.
+// Always false unless the utility has a GameTracker set.
+func (p Player) IsInvulnerable() bool {
+	return p.InvulnerableForTicks() > 0
+}
+
+// returns the number of ticks the player cannot be stunned for because its stun recently ended.
+// Always 0 unless the utility has a GameTracker set.
+func (p Player) InvulnerableForTicks() int {
+	if p.utility.tracker == nil {
+		return 0
+	}
+	return p.utility.tracker.InvulnerableForTicks(p.info.ID)
+}
+
 func (p Player) GetPoints() int {
 	return p.info.Points
 }
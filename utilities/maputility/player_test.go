@@ -0,0 +1,58 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_GetPlayer(t *testing.T) {
+	mu := New(models.Map{
+		Width:  3,
+		Height: 3,
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "myId", Position: 0},
+			{ID: "other", Position: 8},
+		},
+	}, nil, "myId")
+
+	p, ok := mu.GetPlayer("other")
+	assert.True(t, ok)
+	assert.Equal(t, models.Coordinates{X: 2, Y: 2}, p.GetPos())
+
+	_, ok = mu.GetPlayer("missing")
+	assert.False(t, ok)
+
+	assert.Len(t, mu.Players(), 2)
+	if opponents := mu.Opponents(); assert.Len(t, opponents, 1) {
+		assert.Equal(t, "other", opponents[0].GetID())
+	}
+
+	p, ok = mu.PlayerAt(models.Coordinates{X: 0, Y: 0})
+	assert.True(t, ok)
+	assert.Equal(t, "myId", p.GetID())
+	_, ok = mu.PlayerAt(models.Coordinates{X: 1, Y: 1})
+	assert.False(t, ok)
+}
+
+func TestMapUtility_missingCurrentPlayerDoesNotPanic(t *testing.T) {
+	mu := New(models.Map{
+		Width:          3,
+		Height:         3,
+		CharacterInfos: []models.CharacterInfo{{ID: "other", Position: 8}},
+	}, nil, "myId")
+	mu.SetGraph(GraphOfMap(*mu))
+
+	assert.NotPanics(t, func() {
+		assert.False(t, mu.CanIMoveInDirection(models.Right))
+		assert.False(t, mu.IsAnyPlayerWithinExplosionRange())
+		assert.Nil(t, mu.ListCoordinatesColouredByPlayer("myId"))
+
+		_, err := mu.DistanceTo(models.Coordinates{X: 1, Y: 1})
+		assert.Error(t, err)
+		_, err = mu.ShortestPathTo(models.Coordinates{X: 1, Y: 1})
+		assert.Error(t, err)
+	})
+}
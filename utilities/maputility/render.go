@@ -0,0 +1,109 @@
+package maputility
+
+import (
+	"fmt"
+	"strings"
+
+	"paintbot-client/models"
+)
+
+// DefaultOverlayMarker marks the overlaid tiles when RenderOptions.OverlayMarker is not set
+const DefaultOverlayMarker = 'o'
+
+// ANSI background colours of the players, in the order of CharacterInfos
+var playerColours = []string{"41", "42", "43", "44", "45", "46"}
+
+const ansiReset = "\x1b[0m"
+
+// RenderOptions changes how Render draws the map
+type RenderOptions struct {
+	// Colour the tiles and players with ANSI colours, for terminals
+	Colour bool
+	// Overlay marks the given tiles, e.g. Path.Coordinates()
+	Overlay []models.Coordinates
+	// OverlayMarker marks the overlaid tiles, DefaultOverlayMarker if zero
+	OverlayMarker rune
+	// HideLegend leaves out the player list below the map
+	HideLegend bool
+}
+
+// Render draws the map as text, two characters per tile:
+//
+//	##  obstacle
+//	+   power-up
+//	.   unpainted
+//	a   coloured by the first player, b by the second and so on
+//	A   the first player, followed by ! if stunned or * if carrying a power-up
+//
+// Overlaid tiles have the overlay marker as the second character.
+// A legend of the players follows the map.
+func (u *MapUtility) Render() string {
+	return u.RenderWith(RenderOptions{})
+}
+
+// RenderWith draws the map as text, see Render
+func (u *MapUtility) RenderWith(opts RenderOptions) string {
+	marker := opts.OverlayMarker
+	if marker == 0 {
+		marker = DefaultOverlayMarker
+	}
+	overlay := make(map[int]bool, len(opts.Overlay))
+	for _, c := range opts.Overlay {
+		if !u.IsCoordinatesOutOfBounds(c) {
+			overlay[u.ConvertCoordinatesToPosition(c)] = true
+		}
+	}
+
+	g := u.tiles()
+	var b strings.Builder
+	for y := 0; y < u.mapp.Height; y++ {
+		for x := 0; x < u.mapp.Width; x++ {
+			pos := u.ConvertCoordinatesToPosition(models.Coordinates{X: x, Y: y})
+			cell, colour := u.renderTile(g, pos)
+			if overlay[pos] && cell[1] == ' ' {
+				cell = cell[:1] + string(marker)
+			}
+			if opts.Colour && colour != noPlayer {
+				cell = "\x1b[" + playerColours[colour%len(playerColours)] + "m" + cell + ansiReset
+			}
+			b.WriteString(cell)
+		}
+		b.WriteByte('\n')
+	}
+
+	if !opts.HideLegend {
+		for i, info := range u.mapp.CharacterInfos {
+			fmt.Fprintf(&b, "%c %s (%s) points: %d tiles: %d\n",
+				playerLetter(i, 'A'), info.Name, info.ID, info.Points, g.owned[i])
+		}
+	}
+	return b.String()
+}
+
+// renderTile returns the two characters of the tile and the index of the player to colour it by
+func (u *MapUtility) renderTile(g *tileGrid, pos int) (string, int) {
+	if i := g.occupant(pos); i != noPlayer {
+		info := u.mapp.CharacterInfos[i]
+		status := ' '
+		if info.StunnedForGameTicks > 0 {
+			status = '!'
+		} else if info.CarryingPowerUp {
+			status = '*'
+		}
+		return string(playerLetter(i, 'A')) + string(status), i
+	}
+	switch g.tile(pos) {
+	case models.Obstacle:
+		return "##", noPlayer
+	case models.PowerUp:
+		return "+ ", g.owner(pos)
+	}
+	if owner := g.owner(pos); owner != noPlayer {
+		return string(playerLetter(owner, 'a')) + " ", owner
+	}
+	return ". ", noPlayer
+}
+
+func playerLetter(i int, first rune) rune {
+	return first + rune(i%26)
+}
@@ -0,0 +1,51 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func renderTestMap() *MapUtility {
+	// 0 1 2
+	// 3 4 5
+	return New(models.Map{
+		Width:               3,
+		Height:              2,
+		ObstacleUpPositions: []int{4},
+		PowerUpPositions:    []int{5},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "1", Name: "one", Position: 0, ColouredPosition: []int{0, 1}, Points: 2, CarryingPowerUp: true},
+			{ID: "2", Name: "two", Position: 3, ColouredPosition: []int{3, 5}, StunnedForGameTicks: 3},
+		},
+	}, nil, "1")
+}
+
+func TestMapUtility_Render(t *testing.T) {
+	mu := renderTestMap()
+
+	assert.Equal(t, ""+
+		"A*a . \n"+
+		"B!##+ \n"+
+		"A one (1) points: 2 tiles: 2\n"+
+		"B two (2) points: 0 tiles: 2\n",
+		mu.Render())
+}
+
+func TestMapUtility_RenderWith(t *testing.T) {
+	mu := renderTestMap()
+
+	rendered := mu.RenderWith(RenderOptions{
+		Overlay:    []models.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 5, Y: 5}},
+		HideLegend: true,
+	})
+	assert.Equal(t, "A*ao.o\nB!##+ \n", rendered)
+
+	rendered = mu.RenderWith(RenderOptions{Colour: true, HideLegend: true})
+	assert.Equal(t, ""+
+		"\x1b[41mA*\x1b[0m\x1b[41ma \x1b[0m. \n"+
+		"\x1b[42mB!\x1b[0m##\x1b[42m+ \x1b[0m\n",
+		rendered)
+}
@@ -0,0 +1,146 @@
+package maputility
+
+import "paintbot-client/models"
+
+// Territory partitions the map by which player can reach every tile first.
+// Stunned players start moving once their stun ends and players do not block each other.
+type Territory struct {
+	utility *MapUtility
+	// first holds the index of the player reaching every position first, noPlayer if contested or unreachable
+	first []int
+	// ticks holds the number of ticks before the first player can be on every position
+	ticks []int
+	// margin holds the number of ticks the first player is ahead of the next, Unreachable if no one else can get there
+	margin []int
+}
+
+// Territory returns the partition of the map by which player can reach every tile first
+func (u *MapUtility) Territory() *Territory {
+	g := u.tiles()
+	t := &Territory{
+		utility: u,
+		first:   make([]int, len(g.tiles)),
+		ticks:   make([]int, len(g.tiles)),
+		margin:  make([]int, len(g.tiles)),
+	}
+	second := make([]int, len(g.tiles))
+	for i := range t.first {
+		t.first[i] = noPlayer
+		t.ticks[i] = Unreachable
+		second[i] = Unreachable
+	}
+
+	contested := make([]bool, len(g.tiles))
+	for i, p := range u.Players() {
+		field := u.DistanceFieldFrom(p.GetPos())
+		for _, pos := range field.Reachable() {
+			ticks := field.Distance(pos) + p.StunnedForTicks()
+			switch {
+			case t.ticks[pos] == Unreachable || ticks < t.ticks[pos]:
+				second[pos] = t.ticks[pos]
+				t.ticks[pos] = ticks
+				t.first[pos] = i
+				contested[pos] = false
+			case ticks == t.ticks[pos]:
+				second[pos] = ticks
+				contested[pos] = true
+			case second[pos] == Unreachable || ticks < second[pos]:
+				second[pos] = ticks
+			}
+		}
+	}
+
+	for pos := range t.first {
+		if contested[pos] {
+			t.first[pos] = noPlayer
+		}
+		t.margin[pos] = Unreachable
+		if second[pos] != Unreachable {
+			t.margin[pos] = second[pos] - t.ticks[pos]
+		}
+	}
+	return t
+}
+
+// FirstPlayer returns the player that reaches the tile before everyone else.
+// Returns false if the tile is contested or cannot be reached.
+func (t *Territory) FirstPlayer(c models.Coordinates) (Player, bool) {
+	pos, ok := t.position(c)
+	if !ok || t.first[pos] == noPlayer {
+		return Player{}, false
+	}
+	return t.utility.playerAtIndex(t.first[pos]), true
+}
+
+// TicksTo returns the number of ticks before any player can be on the tile
+// and false if no player can reach it
+func (t *Territory) TicksTo(c models.Coordinates) (int, bool) {
+	pos, ok := t.position(c)
+	if !ok || t.ticks[pos] == Unreachable {
+		return Unreachable, false
+	}
+	return t.ticks[pos], true
+}
+
+// Margin returns by how many ticks the first player reaches the tile before the next,
+// 0 for contested tiles. Returns false if at most one player can reach the tile.
+func (t *Territory) Margin(c models.Coordinates) (int, bool) {
+	pos, ok := t.position(c)
+	if !ok || t.margin[pos] == Unreachable {
+		return Unreachable, false
+	}
+	return t.margin[pos], true
+}
+
+// IsContested returns true if several players can reach the tile at the same tick
+func (t *Territory) IsContested(c models.Coordinates) bool {
+	margin, ok := t.Margin(c)
+	return ok && margin == 0
+}
+
+// TilesOf returns the tiles the player reaches before everyone else
+func (t *Territory) TilesOf(playerID string) []models.Coordinates {
+	i, ok := t.utility.playerIndex()[playerID]
+	if !ok {
+		return nil
+	}
+	var tiles []models.Coordinates
+	for pos, first := range t.first {
+		if first == i {
+			tiles = append(tiles, t.utility.ConvertPositionToCoordinates(pos))
+		}
+	}
+	return tiles
+}
+
+// ContestedTiles returns the tiles several players can reach at the same tick
+func (t *Territory) ContestedTiles() []models.Coordinates {
+	var tiles []models.Coordinates
+	for pos := range t.first {
+		if t.margin[pos] == 0 {
+			tiles = append(tiles, t.utility.ConvertPositionToCoordinates(pos))
+		}
+	}
+	return tiles
+}
+
+// Sizes returns the number of tiles every player reaches first, by player ID
+func (t *Territory) Sizes() map[string]int {
+	sizes := map[string]int{}
+	for _, p := range t.utility.Players() {
+		sizes[p.GetID()] = 0
+	}
+	for _, first := range t.first {
+		if first != noPlayer {
+			sizes[t.utility.mapp.CharacterInfos[first].ID]++
+		}
+	}
+	return sizes
+}
+
+func (t *Territory) position(c models.Coordinates) (int, bool) {
+	if t.utility.IsCoordinatesOutOfBounds(c) {
+		return 0, false
+	}
+	return t.utility.ConvertCoordinatesToPosition(c), true
+}
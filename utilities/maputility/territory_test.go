@@ -0,0 +1,50 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_Territory(t *testing.T) {
+	// 0 1 2 3 4 5 #
+	mu := New(models.Map{
+		Width:               7,
+		Height:              1,
+		ObstacleUpPositions: []int{6},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "a", Position: 0},
+			{ID: "b", Position: 4},
+			{ID: "c", Position: 5, StunnedForGameTicks: 5},
+		},
+	}, nil, "a")
+
+	territory := mu.Territory()
+
+	p, ok := territory.FirstPlayer(models.Coordinates{X: 1, Y: 0})
+	assert.True(t, ok)
+	assert.Equal(t, "a", p.GetID())
+	margin, ok := territory.Margin(models.Coordinates{X: 1, Y: 0})
+	assert.True(t, ok)
+	assert.Equal(t, 2, margin)
+
+	// a and b both need two ticks
+	assert.True(t, territory.IsContested(models.Coordinates{X: 2, Y: 0}))
+	_, ok = territory.FirstPlayer(models.Coordinates{X: 2, Y: 0})
+	assert.False(t, ok)
+
+	// c is stunned, b gets there first
+	p, _ = territory.FirstPlayer(models.Coordinates{X: 5, Y: 0})
+	assert.Equal(t, "b", p.GetID())
+	ticks, _ := territory.TicksTo(models.Coordinates{X: 5, Y: 0})
+	assert.Equal(t, 1, ticks)
+
+	_, ok = territory.TicksTo(models.Coordinates{X: 6, Y: 0})
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string]int{"a": 2, "b": 3, "c": 0}, territory.Sizes())
+	assert.Equal(t, []models.Coordinates{{X: 2, Y: 0}}, territory.ContestedTiles())
+	assert.Len(t, territory.TilesOf("b"), 3)
+}
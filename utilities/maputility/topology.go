@@ -0,0 +1,213 @@
+package maputility
+
+import "paintbot-client/models"
+
+// TileKind classifies a tile by the shape of the map around it
+type TileKind string
+
+const (
+	// an obstacle or outside the map
+	Blocked TileKind = "BLOCKED"
+	// a walkable tile with at most one walkable neighbour
+	DeadEnd TileKind = "DEAD_END"
+	// a walkable tile with exactly two walkable neighbours
+	Corridor TileKind = "CORRIDOR"
+	// a walkable tile with three or more walkable neighbours
+	Room TileKind = "ROOM"
+)
+
+// Topology describes the shape of the static obstacle layout of a map.
+// Obstacles never move, so it only needs to be computed once per game.
+type Topology struct {
+	width        int
+	height       int
+	kinds        []TileKind
+	articulation []bool
+	// component holds the index of the connected component of every walkable position, Unreachable for obstacles
+	component      []int
+	componentSizes []int
+}
+
+// AnalyzeTopology analyses the obstacle layout of the map. Players and power-ups are ignored.
+func (u *MapUtility) AnalyzeTopology() *Topology {
+	g := u.tiles()
+	t := &Topology{
+		width:        g.width,
+		height:       g.height,
+		kinds:        make([]TileKind, len(g.tiles)),
+		articulation: make([]bool, len(g.tiles)),
+		component:    make([]int, len(g.tiles)),
+	}
+
+	var neighbours [4]int
+	for pos := range g.tiles {
+		t.component[pos] = Unreachable
+		if !g.walkable(pos) {
+			t.kinds[pos] = Blocked
+			continue
+		}
+		degree := 0
+		for _, n := range g.neighbours(pos, neighbours[:0]) {
+			if g.walkable(n) {
+				degree++
+			}
+		}
+		switch {
+		case degree <= 1:
+			t.kinds[pos] = DeadEnd
+		case degree == 2:
+			t.kinds[pos] = Corridor
+		default:
+			t.kinds[pos] = Room
+		}
+	}
+
+	t.findArticulationPoints(g)
+	return t
+}
+
+// findArticulationPoints labels the connected components and finds the positions whose removal
+// would split their component, with an iterative version of Tarjan's algorithm
+func (t *Topology) findArticulationPoints(g *tileGrid) {
+	size := len(g.tiles)
+	discovered := make([]int, size)
+	low := make([]int, size)
+	parent := make([]int, size)
+	for i := range discovered {
+		discovered[i] = Unreachable
+		parent[i] = Unreachable
+	}
+
+	type frame struct {
+		pos        int
+		neighbours []int
+		next       int
+	}
+
+	time := 0
+	for root := range g.tiles {
+		if !g.walkable(root) || discovered[root] != Unreachable {
+			continue
+		}
+		component := len(t.componentSizes)
+		t.componentSizes = append(t.componentSizes, 0)
+		rootChildren := 0
+
+		visit := func(pos int) frame {
+			discovered[pos] = time
+			low[pos] = time
+			time++
+			t.component[pos] = component
+			t.componentSizes[component]++
+			return frame{pos: pos, neighbours: g.neighbours(pos, make([]int, 0, 4))}
+		}
+
+		stack := []frame{visit(root)}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next < len(top.neighbours) {
+				n := top.neighbours[top.next]
+				top.next++
+				if !g.walkable(n) {
+					continue
+				}
+				if discovered[n] == Unreachable {
+					parent[n] = top.pos
+					if top.pos == root {
+						rootChildren++
+					}
+					stack = append(stack, visit(n))
+				} else if n != parent[top.pos] && discovered[n] < low[top.pos] {
+					low[top.pos] = discovered[n]
+				}
+				continue
+			}
+
+			// all neighbours visited, report back to the parent
+			pos := top.pos
+			stack = stack[:len(stack)-1]
+			if p := parent[pos]; p != Unreachable {
+				if low[pos] < low[p] {
+					low[p] = low[pos]
+				}
+				if p != root && low[pos] >= discovered[p] {
+					t.articulation[p] = true
+				}
+			}
+		}
+		t.articulation[root] = rootChildren > 1
+	}
+}
+
+// KindAt returns the kind of the tile, Blocked if it is out of bounds
+func (t *Topology) KindAt(c models.Coordinates) TileKind {
+	pos, ok := t.position(c)
+	if !ok {
+		return Blocked
+	}
+	return t.kinds[pos]
+}
+
+// IsArticulationPoint returns true if blocking the tile would split the walkable area around it in two,
+// making it a chokepoint
+func (t *Topology) IsArticulationPoint(c models.Coordinates) bool {
+	pos, ok := t.position(c)
+	return ok && t.articulation[pos]
+}
+
+// ArticulationPoints returns all tiles for which IsArticulationPoint is true
+func (t *Topology) ArticulationPoints() []models.Coordinates {
+	var coords []models.Coordinates
+	for pos, a := range t.articulation {
+		if a {
+			coords = append(coords, t.coordinates(pos))
+		}
+	}
+	return coords
+}
+
+// TilesOfKind returns all tiles of the given kind
+func (t *Topology) TilesOfKind(kind TileKind) []models.Coordinates {
+	var coords []models.Coordinates
+	for pos, k := range t.kinds {
+		if k == kind {
+			coords = append(coords, t.coordinates(pos))
+		}
+	}
+	return coords
+}
+
+// ComponentOf returns the index of the group of walkable tiles connected to the tile
+// and false if the tile is not walkable
+func (t *Topology) ComponentOf(c models.Coordinates) (int, bool) {
+	pos, ok := t.position(c)
+	if !ok || t.component[pos] == Unreachable {
+		return Unreachable, false
+	}
+	return t.component[pos], true
+}
+
+// ComponentSize returns the number of walkable tiles connected to the tile, the tile included
+func (t *Topology) ComponentSize(c models.Coordinates) int {
+	component, ok := t.ComponentOf(c)
+	if !ok {
+		return 0
+	}
+	return t.componentSizes[component]
+}
+
+// ComponentSizes returns the size of every group of connected walkable tiles, by component index
+func (t *Topology) ComponentSizes() []int {
+	return append([]int(nil), t.componentSizes...)
+}
+
+func (t *Topology) position(c models.Coordinates) (int, bool) {
+	if c.X < 0 || c.Y < 0 || c.X >= t.width || c.Y >= t.height {
+		return 0, false
+	}
+	return c.Y*t.width + c.X, true
+}
+
+func (t *Topology) coordinates(pos int) models.Coordinates {
+	return models.Coordinates{X: pos % t.width, Y: pos / t.width}
+}
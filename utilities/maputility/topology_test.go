@@ -0,0 +1,41 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func TestMapUtility_AnalyzeTopology(t *testing.T) {
+	// .  .  #  .  .
+	// .  .  .  .  .
+	// #  #  #  #  .
+	// .  #  .  .  .
+	mu := New(models.Map{
+		Width:               5,
+		Height:              4,
+		ObstacleUpPositions: []int{2, 10, 11, 12, 13, 16},
+	}, nil, "")
+
+	topology := mu.AnalyzeTopology()
+
+	assert.Equal(t, Blocked, topology.KindAt(models.Coordinates{X: 2, Y: 0}))
+	assert.Equal(t, Blocked, topology.KindAt(models.Coordinates{X: -1, Y: 0}))
+	assert.Equal(t, Room, topology.KindAt(models.Coordinates{X: 1, Y: 1}))
+	assert.Equal(t, Corridor, topology.KindAt(models.Coordinates{X: 4, Y: 2}))
+	assert.Equal(t, DeadEnd, topology.KindAt(models.Coordinates{X: 2, Y: 3}))
+	assert.Equal(t, DeadEnd, topology.KindAt(models.Coordinates{X: 0, Y: 3}))
+
+	assert.ElementsMatch(t, []models.Coordinates{
+		{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1}, {X: 4, Y: 2}, {X: 4, Y: 3}, {X: 3, Y: 3},
+	}, topology.ArticulationPoints())
+	assert.False(t, topology.IsArticulationPoint(models.Coordinates{X: 0, Y: 0}))
+
+	assert.Equal(t, []int{13, 1}, topology.ComponentSizes())
+	assert.Equal(t, 1, topology.ComponentSize(models.Coordinates{X: 0, Y: 3}))
+	_, ok := topology.ComponentOf(models.Coordinates{X: 1, Y: 3})
+	assert.False(t, ok)
+	assert.Len(t, topology.TilesOfKind(DeadEnd), 2)
+}
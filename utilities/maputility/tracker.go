@@ -0,0 +1,204 @@
+package maputility
+
+import "paintbot-client/models"
+
+// DefaultTrackerWindow is the number of ticks of history kept by a GameTracker
+const DefaultTrackerWindow = 20
+
+// PowerUpPickup is a power-up that was picked up by a player
+type PowerUpPickup struct {
+	Coordinates models.Coordinates
+	PlayerID    string
+}
+
+// TickDelta holds what changed on the map between two consecutive map updates
+type TickDelta struct {
+	GameTick int
+	// Recoloured holds the tiles newly coloured by every player, by player ID
+	Recoloured map[string][]models.Coordinates
+	// PowerUpsSpawned holds the power-ups that appeared
+	PowerUpsSpawned []models.Coordinates
+	// PowerUpsPickedUp holds the power-ups that were picked up and by whom
+	PowerUpsPickedUp []PowerUpPickup
+	// PowerUpsRemoved holds the power-ups that disappeared without being picked up
+	PowerUpsRemoved []models.Coordinates
+	// StunsStarted holds the IDs of the players that got stunned
+	StunsStarted []string
+	// StunsEnded holds the IDs of the players that are no longer stunned
+	StunsEnded []string
+	// PointChanges holds the change in points of every player, by player ID
+	PointChanges map[string]int
+	// Actions holds the action every player most likely took, by player ID
+	Actions map[string]InferredAction
+}
+
+// GameTracker follows a game over successive map updates, keeping the maps and
+// the changes between them for a window of the most recent ticks
+type GameTracker struct {
+	window int
+	gameID string
+	ticks  []trackedTick
+	// invulnerableUntil holds the first game tick every player can be stunned again, by player ID
+	invulnerableUntil map[string]int
+}
+
+type trackedTick struct {
+	event models.MapUpdateEvent
+	delta TickDelta
+}
+
+// NewGameTracker returns a tracker keeping the given number of ticks of history
+func NewGameTracker(window int) *GameTracker {
+	if window < 1 {
+		window = 1
+	}
+	return &GameTracker{window: window, invulnerableUntil: map[string]int{}}
+}
+
+// Update consumes the next map update, in a game played with the given settings,
+// and returns what changed since the previous one.
+// The history is reset when the update belongs to a new game or is not newer than the previous one,
+// in which case the returned delta is empty.
+func (t *GameTracker) Update(e models.MapUpdateEvent, settings models.GameSettings) TickDelta {
+	if prev, ok := t.latest(); !ok || e.GameID != t.gameID || e.GameTick <= prev.event.GameTick {
+		t.Reset()
+	}
+	t.gameID = e.GameID
+
+	delta := TickDelta{GameTick: e.GameTick}
+	if prev, ok := t.latest(); ok {
+		delta = diffMaps(prev.event.Map, e.Map)
+		delta.GameTick = e.GameTick
+		t.trackInvulnerability(prev.event, delta, settings)
+	}
+
+	t.ticks = append(t.ticks, trackedTick{event: e, delta: delta})
+	if len(t.ticks) > t.window {
+		t.ticks = append(t.ticks[:0], t.ticks[len(t.ticks)-t.window:]...)
+	}
+	return delta
+}
+
+// Reset forgets all history
+func (t *GameTracker) Reset() {
+	t.gameID = ""
+	t.ticks = nil
+	t.invulnerableUntil = map[string]int{}
+}
+
+// InvulnerableForTicks returns the number of ticks, from the most recent update,
+// that the player cannot be stunned for after its last stun ended
+func (t *GameTracker) InvulnerableForTicks(playerID string) int {
+	latest, ok := t.latest()
+	if !ok {
+		return 0
+	}
+	until, ok := t.invulnerableUntil[playerID]
+	if !ok || until <= latest.event.GameTick {
+		return 0
+	}
+	return until - latest.event.GameTick
+}
+
+// trackInvulnerability starts the invulnerability of the players whose stun ended
+func (t *GameTracker) trackInvulnerability(prev models.MapUpdateEvent, delta TickDelta, settings models.GameSettings) {
+	before := New(prev.Map, nil, "")
+	for _, id := range delta.StunsEnded {
+		p, ok := before.GetPlayer(id)
+		if !ok {
+			continue
+		}
+		// updates may have been missed, the stun ended when the last seen stun ran out
+		ended := prev.GameTick + p.StunnedForTicks()
+		t.invulnerableUntil[id] = ended + settings.NOOFTicksInvulnerableAfterStun
+	}
+}
+
+// Latest returns the changes of the most recent update and false if there is none
+func (t *GameTracker) Latest() (TickDelta, bool) {
+	latest, ok := t.latest()
+	return latest.delta, ok
+}
+
+// History returns the changes of the tracked ticks, oldest first
+func (t *GameTracker) History() []TickDelta {
+	deltas := make([]TickDelta, len(t.ticks))
+	for i := range t.ticks {
+		deltas[i] = t.ticks[i].delta
+	}
+	return deltas
+}
+
+// Map returns the map from the given number of updates ago, 0 being the most recent,
+// and false if it is not within the tracked window
+func (t *GameTracker) Map(ticksAgo int) (models.Map, bool) {
+	i := len(t.ticks) - 1 - ticksAgo
+	if ticksAgo < 0 || i < 0 {
+		return models.Map{}, false
+	}
+	return t.ticks[i].event.Map, true
+}
+
+func (t *GameTracker) latest() (trackedTick, bool) {
+	if len(t.ticks) == 0 {
+		return trackedTick{}, false
+	}
+	return t.ticks[len(t.ticks)-1], true
+}
+
+// diffMaps returns the changes from one map to the next
+func diffMaps(prev, curr models.Map) TickDelta {
+	before := New(prev, nil, "")
+	after := New(curr, nil, "")
+	delta := TickDelta{
+		Recoloured:   map[string][]models.Coordinates{},
+		PointChanges: map[string]int{},
+		Actions:      InferActions(prev, curr),
+	}
+
+	// Recoloured tiles
+	gb, ga := before.tiles(), after.tiles()
+	for pos := range ga.owners {
+		owner := ga.owner(pos)
+		if owner == noPlayer {
+			continue
+		}
+		id := curr.CharacterInfos[owner].ID
+		if previous := gb.owner(pos); previous == noPlayer || prev.CharacterInfos[previous].ID != id {
+			delta.Recoloured[id] = append(delta.Recoloured[id], after.ConvertPositionToCoordinates(pos))
+		}
+	}
+
+	// Power-ups
+	for pos := range ga.tiles {
+		had := gb.tile(pos) == models.PowerUp
+		has := ga.tile(pos) == models.PowerUp
+		c := after.ConvertPositionToCoordinates(pos)
+		switch {
+		case has && !had:
+			delta.PowerUpsSpawned = append(delta.PowerUpsSpawned, c)
+		case had && !has:
+			if p, ok := after.PlayerAt(c); ok {
+				delta.PowerUpsPickedUp = append(delta.PowerUpsPickedUp, PowerUpPickup{Coordinates: c, PlayerID: p.GetID()})
+			} else {
+				delta.PowerUpsRemoved = append(delta.PowerUpsRemoved, c)
+			}
+		}
+	}
+
+	// Stuns and points
+	for _, p := range after.Players() {
+		b, ok := before.GetPlayer(p.GetID())
+		if !ok {
+			continue
+		}
+		if b.StunnedForTicks() == 0 && p.StunnedForTicks() > 0 {
+			delta.StunsStarted = append(delta.StunsStarted, p.GetID())
+		}
+		if b.StunnedForTicks() > 0 && p.StunnedForTicks() == 0 {
+			delta.StunsEnded = append(delta.StunsEnded, p.GetID())
+		}
+		delta.PointChanges[p.GetID()] = p.GetPoints() - b.GetPoints()
+	}
+	return delta
+}
@@ -0,0 +1,96 @@
+package maputility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"paintbot-client/models"
+)
+
+func update(gameID string, tick int, m models.Map) models.MapUpdateEvent {
+	m.Width, m.Height, m.WorldTick = 3, 3, tick
+	return models.MapUpdateEvent{GameID: gameID, GameTick: tick, Map: m}
+}
+
+func TestGameTracker_Update(t *testing.T) {
+	tracker := NewGameTracker(2)
+	settings := models.DefaultGameSettings()
+
+	delta := tracker.Update(update("game", 1, models.Map{
+		PowerUpPositions: []int{1, 8},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "a", Position: 0, ColouredPosition: []int{0}},
+			{ID: "b", Position: 4, ColouredPosition: []int{4, 5}, StunnedForGameTicks: 1},
+		},
+	}), settings)
+	assert.Equal(t, TickDelta{GameTick: 1}, delta)
+
+	delta = tracker.Update(update("game", 2, models.Map{
+		PowerUpPositions: []int{6},
+		CharacterInfos: []models.CharacterInfo{
+			{ID: "a", Position: 1, ColouredPosition: []int{0, 1, 5}, Points: 3, CarryingPowerUp: true},
+			{ID: "b", Position: 4, ColouredPosition: []int{4}, Points: 1},
+		},
+	}), settings)
+	assert.Equal(t, 2, delta.GameTick)
+	assert.Equal(t, map[string][]models.Coordinates{"a": {{X: 1, Y: 0}, {X: 2, Y: 1}}}, delta.Recoloured)
+	assert.Equal(t, []models.Coordinates{{X: 0, Y: 2}}, delta.PowerUpsSpawned)
+	assert.Equal(t, []PowerUpPickup{{Coordinates: models.Coordinates{X: 1, Y: 0}, PlayerID: "a"}}, delta.PowerUpsPickedUp)
+	assert.Equal(t, []models.Coordinates{{X: 2, Y: 2}}, delta.PowerUpsRemoved)
+	assert.Equal(t, []string{"b"}, delta.StunsEnded)
+	assert.Empty(t, delta.StunsStarted)
+	assert.Equal(t, map[string]int{"a": 3, "b": 1}, delta.PointChanges)
+
+	latest, ok := tracker.Latest()
+	assert.True(t, ok)
+	assert.Equal(t, delta, latest)
+
+	tracker.Update(update("game", 3, models.Map{}), settings)
+	assert.Len(t, tracker.History(), 2)
+	_, ok = tracker.Map(1)
+	assert.True(t, ok)
+	_, ok = tracker.Map(2)
+	assert.False(t, ok)
+
+	// a new game starts from scratch
+	tracker.Update(update("next", 1, models.Map{}), settings)
+	assert.Len(t, tracker.History(), 1)
+}
+
+func TestGameTracker_Invulnerability(t *testing.T) {
+	tracker := NewGameTracker(DefaultTrackerWindow)
+	settings := models.DefaultGameSettings()
+	settings.NOOFTicksInvulnerableAfterStun = 4
+
+	stunned := func(tick, ticks int) models.MapUpdateEvent {
+		return update("game", tick, models.Map{
+			CharacterInfos: []models.CharacterInfo{
+				{ID: "myId", Position: 0},
+				{ID: "other", Position: 1, StunnedForGameTicks: ticks},
+			},
+		})
+	}
+
+	tracker.Update(stunned(1, 1), settings)
+	assert.Equal(t, 0, tracker.InvulnerableForTicks("other"))
+
+	e := stunned(2, 0)
+	tracker.Update(e, settings)
+	assert.Equal(t, 4, tracker.InvulnerableForTicks("other"))
+
+	mu := New(e.Map, nil, "myId")
+	mu.SetTracker(tracker)
+	other, _ := mu.GetPlayer("other")
+	assert.True(t, other.IsInvulnerable())
+	assert.Equal(t, 4, other.InvulnerableForTicks())
+
+	explosion, _ := mu.ExplosionAt("myId", models.Coordinates{X: 0, Y: 0})
+	assert.Empty(t, explosion.Stunned)
+	assert.Len(t, explosion.Invulnerable, 1)
+
+	tracker.Update(stunned(4, 0), settings)
+	assert.Equal(t, 2, tracker.InvulnerableForTicks("other"))
+	tracker.Update(stunned(6, 0), settings)
+	assert.False(t, other.IsInvulnerable())
+}